@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// Sizes of the blocks a slab allocates. Blocks start small so that small
+// values don't waste memory, and grow up to the maximum for large values.
+const (
+	minSlabSize = 8
+	maxSlabSize = 1024
+)
+
+// slab allocates values of type T in blocks, reducing the number of
+// allocations when converting large values. A value allocated from a slab
+// keeps its entire block alive, so slabs are only used for the values
+// making up a single result.
+type slab[T any] struct {
+	buf  []T
+	size int
+}
+
+func (s *slab[T]) new() *T {
+	if len(s.buf) == 0 {
+		s.size = min(max(s.size*2, minSlabSize), maxSlabSize)
+		s.buf = make([]T, s.size)
+	}
+
+	p := &s.buf[0]
+	s.buf = s.buf[1:]
+	return p
+}
+
+// valueAlloc allocates the messages making up a Value.
+type valueAlloc struct {
+	values    slab[proto.Value]
+	bools     slab[proto.Value_ValueBool]
+	ints      slab[proto.Value_ValueInt]
+	floats    slab[proto.Value_ValueFloat]
+	strings   slab[proto.Value_ValueString]
+	lists     slab[proto.Value_ValueList]
+	listElems slab[proto.Value_List]
+	maps      slab[proto.Value_ValueMap]
+	mapElems  slab[proto.Value_Map]
+}
+
+func (a *valueAlloc) value(typ proto.Value_Type) *proto.Value {
+	v := a.values.new()
+	v.Type = typ
+	return v
+}
+
+func (a *valueAlloc) boolValue(b bool) *proto.Value {
+	v := a.value(proto.Value_BOOL)
+	x := a.bools.new()
+	x.ValueBool = b
+	v.Value = x
+	return v
+}
+
+func (a *valueAlloc) intValue(i int64) *proto.Value {
+	v := a.value(proto.Value_INT)
+	x := a.ints.new()
+	x.ValueInt = i
+	v.Value = x
+	return v
+}
+
+func (a *valueAlloc) floatValue(f float64) *proto.Value {
+	v := a.value(proto.Value_FLOAT)
+	x := a.floats.new()
+	x.ValueFloat = f
+	v.Value = x
+	return v
+}
+
+func (a *valueAlloc) stringValue(s string) *proto.Value {
+	v := a.value(proto.Value_STRING)
+	x := a.strings.new()
+	x.ValueString = s
+	v.Value = x
+	return v
+}
+
+func (a *valueAlloc) listValue(elems []*proto.Value) *proto.Value {
+	v := a.value(proto.Value_LIST)
+	x := a.lists.new()
+	x.ValueList = a.listElems.new()
+	x.ValueList.Elems = elems
+	v.Value = x
+	return v
+}
+
+func (a *valueAlloc) mapValue(elems []*proto.Value_KV) *proto.Value {
+	v := a.value(proto.Value_MAP)
+	x := a.maps.new()
+	x.ValueMap = a.mapElems.new()
+	x.ValueMap.Elems = elems
+	v.Value = x
+	return v
+}
+
+// kvs returns n map elements, allocated together.
+func kvs(n int) []*proto.Value_KV {
+	elems := make([]proto.Value_KV, n)
+	result := make([]*proto.Value_KV, n)
+	for i := range elems {
+		result[i] = &elems[i]
+	}
+
+	return result
+}
@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// minDecimalPrec is the minimum precision in bits of a big.Float parsed
+// from a decimal, which is enough for any int64 or uint64.
+const minDecimalPrec = 64
+
+func decimalValue(v string) *proto.Value {
+	return &proto.Value{
+		Type:  proto.Value_DECIMAL,
+		Value: &proto.Value_ValueDecimal{ValueDecimal: v},
+	}
+}
+
+// toValue_decimal converts big numbers and unsigned integers that don't
+// fit in an int64 to decimals. It returns false if v is not one of these,
+// or is a big.Rat without a finite decimal representation.
+func toValue_decimal(v reflect.Value) (*proto.Value, bool) {
+	// Pointers to big numbers implement the marshaler interfaces, so
+	// they must be dereferenced here rather than by the caller
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		switch v.Type().Elem() {
+		case bigIntTyp, bigFloatTyp, bigRatTyp:
+			v = v.Elem()
+		}
+	}
+
+	switch v.Type() {
+	case bigIntTyp:
+		return decimalValue(addr(v).Interface().(*big.Int).String()), true
+
+	case bigFloatTyp:
+		f := addr(v).Interface().(*big.Float)
+		if f.IsInf() {
+			// Infinity is not a decimal, but it is a float
+			f64, _ := f.Float64()
+			return floatValue(f64), true
+		}
+
+		return decimalValue(f.Text('g', -1)), true
+
+	case bigRatTyp:
+		r := addr(v).Interface().(*big.Rat)
+		n, exact := r.FloatPrec()
+		if !exact {
+			return nil, false
+		}
+
+		return decimalValue(r.FloatString(n)), true
+	}
+
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u > math.MaxInt64 {
+			return decimalValue(new(big.Int).SetUint64(u).String()), true
+		}
+	}
+
+	return nil, false
+}
+
+// parseDecimal parses the string of a DECIMAL value as a big.Float, with
+// enough precision to hold every digit of the string. Decimals with a
+// fractional part may not be exactly representable, see parseDecimalRat.
+func parseDecimal(s string) (*big.Float, error) {
+	r, err := parseDecimalRat(s)
+	if err != nil {
+		return nil, err
+	}
+
+	prec := max(uint(len(s))*4, minDecimalPrec)
+	return new(big.Float).SetPrec(prec).SetRat(r), nil
+}
+
+// parseDecimalRat parses the string of a DECIMAL value exactly.
+func parseDecimalRat(s string) (*big.Rat, error) {
+	// Rat also accepts fractions such as "1/3" and other bases, which
+	// aren't decimals
+	if strings.Trim(s, "0123456789+-.eE") != "" {
+		return nil, fmt.Errorf("invalid decimal: %q", s)
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal: %q", s)
+	}
+
+	return r, nil
+}
+
+// decimalInt returns the integer of a DECIMAL value, or an error if it
+// has a fractional part.
+func decimalInt(raw *proto.Value) (*big.Int, error) {
+	r, err := parseDecimalRat(raw.GetValueDecimal())
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.IsInt() {
+		return nil, fmt.Errorf(
+			"cannot convert decimal with fractional part to integer: %s",
+			raw.GetValueDecimal())
+	}
+
+	return r.Num(), nil
+}
+
+// decimalToGo converts a DECIMAL value for an interface{} target. Integers
+// are a big.Int, and all other decimals are a big.Rat so that they remain
+// exact.
+func decimalToGo(raw *proto.Value) (interface{}, error) {
+	r, err := parseDecimalRat(raw.GetValueDecimal())
+	if err != nil {
+		return nil, err
+	}
+
+	if r.IsInt() {
+		return *r.Num(), nil
+	}
+
+	return *r, nil
+}
+
+// compareDecimals compares the numeric values of two INT, FLOAT or
+// DECIMAL values where at least one is a DECIMAL. NaN is ordered before
+// all other numbers.
+func compareDecimals(a, b *proto.Value) int {
+	ar, ak := ratNumber(a)
+	br, bk := ratNumber(b)
+	if ak != 0 || bk != 0 {
+		return compareInts(int64(ak), int64(bk))
+	}
+
+	return ar.Cmp(br)
+}
+
+// ratNumber returns the exact value of an INT, FLOAT or DECIMAL value.
+// Floats that are not finite have no exact value, and return a nil value
+// and their position relative to finite numbers: -2 for NaN, -1 for
+// negative infinity and 1 for positive infinity. Invalid decimals are
+// ordered as zero, since CompareValues can't return an error.
+func ratNumber(v *proto.Value) (*big.Rat, int) {
+	switch v.Type {
+	case proto.Value_INT:
+		return new(big.Rat).SetInt64(v.GetValueInt()), 0
+
+	case proto.Value_FLOAT:
+		f := v.GetValueFloat()
+		switch {
+		case math.IsNaN(f):
+			return nil, -2
+
+		case math.IsInf(f, -1):
+			return nil, -1
+
+		case math.IsInf(f, 1):
+			return nil, 1
+		}
+
+		return new(big.Rat).SetFloat64(f), 0
+
+	default:
+		r, err := parseDecimalRat(v.GetValueDecimal())
+		if err != nil {
+			return new(big.Rat), 0
+		}
+
+		return r, 0
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"reflect"
+
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// Decoder converts Sentinel values to Go values with configurable
+// behavior. ValueToGo uses a Decoder with the default options.
+//
+// The zero value is ready to use. A Decoder can be used concurrently, but
+// its fields must not be modified while it is in use.
+type Decoder struct {
+	// FieldNaming is the naming strategy used to match map keys to struct
+	// fields that don't set a name in their tag. If this is nil,
+	// LegacySnakeCase is used, as with Encoder.
+	FieldNaming FieldNamer
+
+	// Strict returns an error for conversions that would lose
+	// information or coerce between types, rather than converting them
+	// on a best-effort basis. In strict mode:
+	//
+	//   - integers must fit in the target integer type
+	//   - integers converted to floats must be exactly representable
+	//   - floats converted to float32 must be exactly representable
+	//   - strings are not parsed as numbers, and numbers are not
+	//     formatted as strings, except for fields with the "string" tag
+	//     option
+	//
+	Strict bool
+
+	// FallbackUnmarshalers converts to types that don't implement
+	// SentinelUnmarshaler with json.Unmarshaler or
+	// encoding.TextUnmarshaler, if they implement either, as
+	// encoding/json does. This is disabled by default since existing types
+	// implementing these interfaces are converted by their fields.
+	FallbackUnmarshalers bool
+
+	// DisallowUnknownFields returns an error when decoding a map into a
+	// struct if the map has a key that matches no field.
+	DisallowUnknownFields bool
+
+	// MaxDepth is the maximum nesting depth of lists and maps. Zero means
+	// no limit.
+	MaxDepth int
+
+	// MaxSize is the maximum total number of elements across all lists
+	// and maps in the value. Zero means no limit.
+	MaxSize int
+
+	// Hooks are custom conversions keyed by target type. A hook must
+	// return a value assignable to its type. Hooks take precedence over
+	// all other conversions.
+	Hooks map[reflect.Type]func(*proto.Value) (interface{}, error)
+}
+
+// Decode converts the protobuf Value to a Go value of type t. See
+// ValueToGo for the conversion rules.
+func (d *Decoder) Decode(v *proto.Value, t reflect.Type) (interface{}, error) {
+	s := &decodeState{Decoder: d}
+	return s.valueToGo(v, t)
+}
+
+// decodeState is the state of a single Decode call.
+type decodeState struct {
+	*Decoder
+
+	depth int // current nesting depth of lists and maps
+	size  int // total number of list and map elements so far
+}
+
+// fieldName returns the map key for a struct field without a tag name.
+func (s *decodeState) fieldName(name string) string {
+	return s.FieldNaming.name(name)
+}
+
+// enter is called before converting a list or map with n elements, and
+// checks the depth and size limits. Each call must be paired with a call
+// to leave.
+func (s *decodeState) enter(n int) error {
+	s.depth++
+	s.size += n
+	return checkLimits(s.depth, s.size, s.MaxDepth, s.MaxSize)
+}
+
+func (s *decodeState) leave() {
+	s.depth--
+}
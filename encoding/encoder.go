@@ -0,0 +1,217 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/sentinel-sdk/internal/walk"
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// Encoder converts Go values to Sentinel values with configurable
+// behavior. GoToValue uses an Encoder with the default options.
+//
+// The zero value is ready to use. An Encoder can be used concurrently, but
+// its fields must not be modified while it is in use.
+type Encoder struct {
+	// FieldNaming is the naming strategy for struct fields that don't set
+	// a name in their tag. If this is nil, LegacySnakeCase is used for
+	// backwards compatibility. New plugins should prefer SnakeCase, which
+	// handles acronyms.
+	FieldNaming FieldNamer
+
+	// UnsortedMaps disables sorting the elements of maps by their key,
+	// see CompareValues for the order. Go map iteration order is random,
+	// so without sorting the same data converts to a different value, and
+	// different bytes on the wire, on every call.
+	UnsortedMaps bool
+
+	// Strict returns an error for conversions that would lose
+	// information, rather than converting them on a best-effort basis.
+	// When encoding, this is an unsigned integer too large for an int64.
+	Strict bool
+
+	// Decimals converts big.Int, big.Float and big.Rat values, and
+	// unsigned integers too large for an int64, to decimal values rather
+	// than strings and wrapped integers. This is disabled by default since
+	// hosts that predate decimal values reject them. See the package
+	// documentation for details.
+	Decimals bool
+
+	// Base64Bytes converts []byte values to a string of their standard
+	// base64 encoding, as encoding/json does, rather than a list of
+	// integers. This is disabled by default since policies may index into
+	// the lists returned by existing plugins.
+	Base64Bytes bool
+
+	// FallbackMarshalers converts types that don't implement
+	// SentinelMarshaler with json.Marshaler or encoding.TextMarshaler, if
+	// they implement either, as encoding/json does. This is disabled by
+	// default since existing types implementing these interfaces are
+	// converted by their fields.
+	FallbackMarshalers bool
+
+	// NilAsUndefined converts nil pointers and interfaces to undefined
+	// rather than null.
+	NilAsUndefined bool
+
+	// MaxDepth is the maximum nesting depth of lists and maps, including
+	// maps converted from structs and values returned by marshalers. Zero
+	// means no limit, except for the nesting of marshalers, see
+	// DefaultMaxMarshalerDepth.
+	MaxDepth int
+
+	// MaxSize is the maximum total number of elements across all lists
+	// and maps in the result. Zero means no limit.
+	MaxSize int
+
+	// Hooks are custom conversions keyed by type. A hook returns the value
+	// to convert in place of its argument, as with SentinelMarshaler.
+	// Hooks take precedence over all other conversions.
+	Hooks map[reflect.Type]func(interface{}) (interface{}, error)
+}
+
+// Encode converts the Go value to a protobuf Value. See GoToValue for the
+// conversion rules.
+func (e *Encoder) Encode(raw interface{}) (*proto.Value, error) {
+	s := encodeStatePool.Get().(*encodeState)
+	defer s.release()
+
+	s.Encoder = e
+	return s.toValue(raw)
+}
+
+// defaultEncoder is the Encoder used by GoToValue.
+var defaultEncoder = &Encoder{}
+
+// encodeStatePool pools encodeStates to reuse their internal buffers
+// between calls.
+var encodeStatePool = sync.Pool{
+	New: func() interface{} { return new(encodeState) },
+}
+
+// encodeState is the state of a single Encode call.
+type encodeState struct {
+	*Encoder
+
+	depth int // current nesting depth of lists and maps
+	size  int // total number of list and map elements so far
+
+	// marshalDepth is the current nesting of marshaler calls, see
+	// toValue_marshaled.
+	marshalDepth int
+
+	// path is the path to the value being converted, see walk.FormatPath.
+	path []walk.Elem
+
+	// seen are the pointers, maps and slices currently being converted,
+	// used to detect cycles.
+	seen walk.Seen
+
+	// names caches the map keys for struct field names, see fieldName.
+	names map[string]string
+
+	// alloc allocates the resulting values. It is not reused between
+	// calls since the values are owned by the caller once returned.
+	alloc valueAlloc
+}
+
+// release resets the state and returns it to the pool.
+func (s *encodeState) release() {
+	s.Encoder = nil
+	s.depth = 0
+	s.size = 0
+	s.marshalDepth = 0
+	s.path = s.path[:0]
+	s.seen.Reset()
+	clear(s.names)
+	s.alloc = valueAlloc{}
+	encodeStatePool.Put(s)
+}
+
+// nilValue returns the value for a nil pointer or interface.
+func (s *encodeState) nilValue() *proto.Value {
+	if s.NilAsUndefined {
+		return s.alloc.value(proto.Value_UNDEFINED)
+	}
+
+	return s.alloc.value(proto.Value_NULL)
+}
+
+// fieldName returns the map key for a struct field without a tag name.
+// Keys are cached for the duration of the call, since the naming
+// strategy may be expensive and structs are often converted in bulk.
+func (s *encodeState) fieldName(name string) string {
+	if key, ok := s.names[name]; ok {
+		return key
+	}
+
+	key := s.FieldNaming.name(name)
+
+	if s.names == nil {
+		s.names = make(map[string]string)
+	}
+	s.names[name] = key
+	return key
+}
+
+// enter is called before converting a list or map with n elements, and
+// checks the depth and size limits. Each call must be paired with a call
+// to leave.
+func (s *encodeState) enter(n int) error {
+	s.depth++
+	s.size += n
+	if err := checkLimits(s.depth, s.size, s.MaxDepth, s.MaxSize); err != nil {
+		return fmt.Errorf("%s at %s", err, walk.FormatPath(s.path))
+	}
+
+	return nil
+}
+
+func (s *encodeState) leave() {
+	s.depth--
+}
+
+// push adds a list index or map key to the path of the value being
+// converted. Each call must be paired with a call to pop.
+func (s *encodeState) push(elem walk.Elem) {
+	s.path = append(s.path, elem)
+}
+
+func (s *encodeState) pop() {
+	s.path = s.path[:len(s.path)-1]
+}
+
+// visit is called before converting the contents of a pointer, map or
+// slice, and returns an error if v is already being converted further up,
+// meaning that the value refers back to itself. Each call must be paired
+// with a call to unvisit.
+func (s *encodeState) visit(v reflect.Value) error {
+	if !s.seen.Visit(v) {
+		return fmt.Errorf("cycle detected at %s", walk.FormatPath(s.path))
+	}
+
+	return nil
+}
+
+func (s *encodeState) unvisit(v reflect.Value) {
+	s.seen.Unvisit(v)
+}
+
+// checkLimits returns an error if depth or size exceed their maximums.
+// Maximums of zero are unlimited.
+func checkLimits(depth, size, maxDepth, maxSize int) error {
+	if maxDepth > 0 && depth > maxDepth {
+		return fmt.Errorf("maximum depth of %d exceeded", maxDepth)
+	}
+
+	if maxSize > 0 && size > maxSize {
+		return fmt.Errorf("maximum size of %d elements exceeded", maxSize)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package encoding converts between Go values and the protobuf Value
+// structure used to send Sentinel values across the plugin RPC.
+//
+// # Well-known types
+//
+// Some types from the standard library have a natural Sentinel
+// representation that differs from what reflection on the type would
+// give. These are converted as follows, both for GoToValue and ValueToGo:
+//
+//   - time.Time is a string in RFC 3339 format, with sub-second precision
+//     if present. It can be decoded from an RFC 3339 string or an integer
+//     of seconds since the Unix epoch.
+//
+//   - time.Duration is an integer of nanoseconds. It can be decoded from
+//     an integer of nanoseconds or a string accepted by time.ParseDuration,
+//     such as "1h30m".
+//
+//   - net.IP is a string in the format of net.IP.String.
+//
+//   - url.URL is a string in the format of url.URL.String.
+//
+//   - big.Int is an integer if it fits in an int64, and a decimal string
+//     otherwise. It can be decoded from an integer, a string, or a float
+//     or decimal with no fractional part.
+//
+//   - big.Float is a float if it is exactly representable as a float64,
+//     and a decimal string otherwise. It can be decoded from an integer,
+//     a float, a decimal or a string.
+//
+//   - big.Rat is a string in the format of big.Rat.MarshalText, such as
+//     "1/3". It can be decoded from an integer, a finite float, a
+//     decimal, or a string accepted by big.Rat.SetString.
+//
+//   - []byte is a list of integers, like any other slice. An Encoder with
+//     Base64Bytes set converts it to a string of the standard base64
+//     encoding of the bytes instead, as with encoding/json. It can be
+//     decoded from a base64 string or a list of integers.
+//
+// Pointers to these types are converted the same way. A nil net.IP, or a
+// nil []byte converted to base64, is converted to null.
+//
+// # Custom conversion
+//
+// Mirroring encoding/json, types can control their own conversion by
+// implementing SentinelMarshaler and SentinelUnmarshaler. The conversion
+// of well-known types takes precedence over these.
+//
+// An Encoder with FallbackMarshalers set, or a Decoder with
+// FallbackUnmarshalers set, also falls back to json.Marshaler and
+// json.Unmarshaler, converting through the JSON representation, and then
+// to encoding.TextMarshaler and encoding.TextUnmarshaler, converting to
+// and from a string. These are opt-in, since types implementing them were
+// previously converted by their fields.
+//
+// # JSON
+//
+// Values can be converted to and from JSON in two forms. The plain form of
+// ValueToJSON and JSONToValue is JSON as a person would write it, but
+// can't distinguish integers from floats or undefined from null. The
+// tagged form of ValueToTaggedJSON and TaggedJSONToValue preserves values
+// exactly, and is suited for fixtures and recorded results. MarshalJSON
+// converts a Go value to the plain form, omitting undefined map values.
+//
+// # Decimals
+//
+// Decimal values hold numbers of arbitrary size and precision as a decimal
+// string, for numbers that can't be represented as an integer or float
+// without losing information, such as large unsigned integers or amounts
+// of money.
+//
+// Hosts that predate decimal values reject them, so GoToValue never
+// produces them. Instead, big numbers are converted to strings as
+// described above, and unsigned integers too large for an int64 wrap
+// around to negative integers. An Encoder with Decimals set converts
+// big.Int, big.Float and big.Rat to decimals, as well as unsigned integers
+// too large for an int64. A big.Rat without a finite decimal
+// representation, such as 1/3, is still converted to a string. Only
+// enable this for hosts known to support decimals.
+//
+// Decimals are always decoded. For an interface{} target, a decimal is
+// converted to a big.Int if it is an integer and to a big.Rat otherwise,
+// so that it remains exact. Decimals can also be decoded to big.Float and
+// to integer and float types, returning an error for integers that don't
+// fit or, in strict mode, floats that would be rounded. Decimals can't be
+// used as Go map keys.
+//
+// # Round trips
+//
+// Converting a value with ValueToGo and a nil type and back with GoToValue
+// gives an equal value, with these exceptions:
+//
+//   - map elements are sorted by key, see CompareValues
+//   - maps with list, map or decimal keys can't be converted to Go maps,
+//     and ValueToGo returns an error
+//   - decimals are converted back to integers, floats or strings, unless
+//     using an Encoder with Decimals set
+//
+// Maps with keys of a single scalar type convert to Go maps with that key
+// type, such as map[int64]interface{}, and maps with keys of mixed types
+// convert to map[interface{}]interface{}. Integers and floats remain
+// distinct, so the integer 1 and the float 1.0 are different keys.
+//
+// # Options
+//
+// GoToValue and ValueToGo convert with default options. An Encoder or
+// Decoder can be used instead to change the conversion, such as to reject
+// lossy conversions, limit the depth and size of values, or add custom
+// conversions for types that can't implement the marshaler interfaces:
+//
+//	dec := &encoding.Decoder{Strict: true, DisallowUnknownFields: true}
+//	v, err := dec.Decode(value, reflect.TypeOf(Config{}))
+package encoding
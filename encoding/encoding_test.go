@@ -4,10 +4,25 @@
 package encoding
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"net"
+	"net/url"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"testing/quick"
+	"time"
+
+	protobuf "google.golang.org/protobuf/proto"
 
 	sdk "github.com/hashicorp/sentinel-sdk"
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
 )
 
 func TestEncoding(t *testing.T) {
@@ -56,6 +71,88 @@ type targetType struct {
 	Expected interface{}
 }
 
+func timePtr(t time.Time) *time.Time { return &t }
+
+func mustParseURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return u
+}
+
+func mustParseBigInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer: " + s)
+	}
+
+	return i
+}
+
+// testEmbedded and TestEmbedded are used for embedding in structs
+type testEmbedded struct {
+	Bar int
+	Foo int
+}
+
+type TestEmbedded struct{ Bar int }
+
+// testSentinelMarshaler implements SentinelMarshaler and
+// SentinelUnmarshaler, converting to a string of "A-B".
+type testSentinelMarshaler struct{ A, B int }
+
+func (m testSentinelMarshaler) MarshalSentinel() (interface{}, error) {
+	return fmt.Sprintf("%d-%d", m.A, m.B), nil
+}
+
+func (m *testSentinelMarshaler) UnmarshalSentinel(v *proto.Value) error {
+	_, err := fmt.Sscanf(v.GetValueString(), "%d-%d", &m.A, &m.B)
+	return err
+}
+
+// testJSONMarshaler implements json.Marshaler and json.Unmarshaler,
+// converting to a list of the name and count.
+type testJSONMarshaler struct {
+	Name  string
+	Count int
+}
+
+func (m testJSONMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{m.Name, m.Count})
+}
+
+func (m *testJSONMarshaler) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if len(raw) != 2 {
+		return fmt.Errorf("expected 2 elements, got %d", len(raw))
+	}
+
+	if err := json.Unmarshal(raw[0], &m.Name); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw[1], &m.Count)
+}
+
+// testTextMarshaler implements encoding.TextMarshaler and
+// encoding.TextUnmarshaler, converting to a string in angle brackets.
+type testTextMarshaler struct{ Value string }
+
+func (m testTextMarshaler) MarshalText() ([]byte, error) {
+	return []byte("<" + m.Value + ">"), nil
+}
+
+func (m *testTextMarshaler) UnmarshalText(text []byte) error {
+	m.Value = strings.Trim(string(text), "<>")
+	return nil
+}
+
 // encodingTests are the test cases for all encodings
 var encodingTests = []struct {
 	Name     string
@@ -465,6 +562,348 @@ var encodingTests = []struct {
 		false,
 	},
 
+	{
+		"struct field excluded with dash",
+		struct {
+			Foo int `sentinel:"-"`
+			Bar int
+		}{Foo: 1, Bar: 2},
+		map[string]int64{"bar": 2},
+		false,
+	},
+
+	{
+		"struct field omitempty",
+		struct {
+			Foo int    `sentinel:",omitempty"`
+			Bar string `sentinel:"baz,omitempty"`
+			Qux []int  `sentinel:",omitempty"`
+		}{},
+		map[string]interface{}{},
+		false,
+	},
+
+	{
+		"struct field omitempty set",
+		struct {
+			Foo int    `sentinel:",omitempty"`
+			Bar string `sentinel:"baz,omitempty"`
+		}{Foo: 1, Bar: "x"},
+		map[string]interface{}{"foo": int64(1), "baz": "x"},
+		false,
+	},
+
+	{
+		"struct field string",
+		struct {
+			Foo int     `sentinel:",string"`
+			Bar float64 `sentinel:",string"`
+			Baz bool    `sentinel:",string"`
+			Qux *int    `sentinel:",string"`
+		}{Foo: 42, Bar: 1.5, Baz: true},
+		map[string]interface{}{"foo": "42", "bar": "1.5", "baz": "true", "qux": sdk.Null},
+		false,
+	},
+
+	{
+		"struct field inline",
+		struct {
+			testEmbedded `sentinel:",inline"`
+			Foo          int
+		}{testEmbedded: testEmbedded{Bar: 1, Foo: 2}, Foo: 3},
+		map[string]int64{"foo": 3, "bar": 1},
+		false,
+	},
+
+	{
+		"struct field inline pointer",
+		struct {
+			*testEmbedded `sentinel:",inline"`
+		}{testEmbedded: &testEmbedded{Bar: 1, Foo: 2}},
+		map[string]int64{"foo": 2, "bar": 1},
+		false,
+	},
+
+	{
+		"struct field inline nil pointer",
+		struct {
+			*testEmbedded `sentinel:",inline"`
+		}{},
+		map[string]interface{}{},
+		false,
+	},
+
+	{
+		"struct field embedded not inline",
+		struct {
+			testEmbedded
+		}{testEmbedded: testEmbedded{Bar: 1, Foo: 2}},
+		map[string]interface{}{},
+		false,
+	},
+
+	{
+		"struct field Embedded not inline",
+		struct {
+			TestEmbedded
+		}{TestEmbedded: TestEmbedded{Bar: 1}},
+		map[string]map[string]int64{"test_embedded": {"bar": 1}},
+		false,
+	},
+
+	//-----------------------------------------------------------
+	// Well-known types
+
+	{
+		"time to time",
+		time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+		time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+		false,
+	},
+
+	{
+		"time to string",
+		time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		"2020-01-02T03:04:05Z",
+		false,
+	},
+
+	{
+		"time pointer to time pointer",
+		timePtr(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+		timePtr(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+		false,
+	},
+
+	{
+		"int to time",
+		1577934245,
+		time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		false,
+	},
+
+	{
+		"invalid string to time",
+		"yesterday",
+		time.Time{},
+		true,
+	},
+
+	{
+		"duration to duration",
+		90 * time.Minute,
+		90 * time.Minute,
+		false,
+	},
+
+	{
+		"duration to int",
+		time.Second,
+		int64(1000000000),
+		false,
+	},
+
+	{
+		"string to duration",
+		"1h30m",
+		90 * time.Minute,
+		false,
+	},
+
+	{
+		"IP to IP",
+		net.ParseIP("10.0.0.1"),
+		net.ParseIP("10.0.0.1"),
+		false,
+	},
+
+	{
+		"IP to string",
+		net.ParseIP("10.0.0.1"),
+		"10.0.0.1",
+		false,
+	},
+
+	{
+		"nil IP to null",
+		net.IP(nil),
+		sdk.Null,
+		false,
+	},
+
+	{
+		"invalid string to IP",
+		"nope",
+		net.IP{},
+		true,
+	},
+
+	{
+		"URL to string",
+		mustParseURL("https://example.com/path?q=1"),
+		"https://example.com/path?q=1",
+		false,
+	},
+
+	{
+		"string to URL",
+		"https://example.com/path?q=1",
+		mustParseURL("https://example.com/path?q=1"),
+		false,
+	},
+
+	{
+		"big int to int",
+		big.NewInt(42),
+		int64(42),
+		false,
+	},
+
+	{
+		"large big int to string",
+		mustParseBigInt("123456789012345678901234567890"),
+		"123456789012345678901234567890",
+		false,
+	},
+
+	{
+		"string to big int",
+		"123456789012345678901234567890",
+		*mustParseBigInt("123456789012345678901234567890"),
+		false,
+	},
+
+	{
+		"float to big int",
+		42.5,
+		big.Int{},
+		true,
+	},
+
+	{
+		"big float to float",
+		big.NewFloat(1.5),
+		1.5,
+		false,
+	},
+
+	{
+		"float to big float pointer",
+		1.5,
+		big.NewFloat(1.5),
+		false,
+	},
+
+	{
+		"bytes to int list",
+		[]byte("hi"),
+		[]int64{104, 105},
+		false,
+	},
+
+	{
+		"base64 string to bytes",
+		"aGVsbG8=",
+		[]byte("hello"),
+		false,
+	},
+
+	{
+		"bytes to bytes",
+		[]byte("hello"),
+		[]byte("hello"),
+		false,
+	},
+
+	{
+		"int list to bytes",
+		[]int{104, 105},
+		[]byte("hi"),
+		false,
+	},
+
+	{
+		"out of range int list to bytes",
+		[]int{256},
+		[]byte{},
+		true,
+	},
+
+	//-----------------------------------------------------------
+	// Marshalers
+
+	{
+		"sentinel marshaler to string",
+		testSentinelMarshaler{A: 1, B: 2},
+		"1-2",
+		false,
+	},
+
+	{
+		"sentinel marshaler pointer to string",
+		&testSentinelMarshaler{A: 1, B: 2},
+		"1-2",
+		false,
+	},
+
+	{
+		"string to sentinel unmarshaler",
+		"1-2",
+		testSentinelMarshaler{A: 1, B: 2},
+		false,
+	},
+
+	{
+		"string to sentinel unmarshaler pointer",
+		"1-2",
+		&testSentinelMarshaler{A: 1, B: 2},
+		false,
+	},
+
+	{
+		"invalid string to sentinel unmarshaler",
+		"nope",
+		testSentinelMarshaler{},
+		true,
+	},
+
+	{
+		"sentinel marshaler in struct",
+		struct{ Foo testSentinelMarshaler }{Foo: testSentinelMarshaler{A: 1, B: 2}},
+		map[string]string{"foo": "1-2"},
+		false,
+	},
+
+	// The json and text marshalers are only used if enabled, see
+	// TestEncoder and TestDecoder.
+	{
+		"json marshaler by fields",
+		testJSONMarshaler{Name: "foo", Count: 2},
+		map[string]interface{}{"name": "foo", "count": int64(2)},
+		false,
+	},
+
+	{
+		"map to json unmarshaler by fields",
+		map[string]interface{}{"name": "foo", "count": 2},
+		testJSONMarshaler{Name: "foo", Count: 2},
+		false,
+	},
+
+	{
+		"text marshaler by fields",
+		testTextMarshaler{Value: "foo"},
+		map[string]string{"value": "foo"},
+		false,
+	},
+
+	{
+		"map to text unmarshaler by fields",
+		map[string]string{"value": "foo"},
+		testTextMarshaler{Value: "foo"},
+		false,
+	},
+
 	//-----------------------------------------------------------
 	// Null
 
@@ -499,3 +938,1654 @@ var encodingTests = []struct {
 		false,
 	},
 }
+
+func TestEncoding_structTagErr(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Source interface{}
+	}{
+		{
+			"unknown option",
+			struct {
+				Foo int `sentinel:",nope"`
+			}{},
+		},
+
+		{
+			"inline non-struct",
+			struct {
+				Foo int `sentinel:",inline"`
+			}{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			if _, err := GoToValue(tc.Source); err == nil {
+				t.Fatal("should error")
+			}
+		})
+	}
+}
+
+// Unexported fields are ignored, including any errors in their tags.
+func TestEncoding_structTagUnexported(t *testing.T) {
+	type unexported struct {
+		Foo int
+		bar int `sentinel:",nope"`
+	}
+
+	v, err := GoToValue(unexported{Foo: 1, bar: 2})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := ValueToGo(v, reflect.TypeOf(unexported{}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if expected := (unexported{Foo: 1}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestFieldNaming(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Expected map[string]string // keyed by strategy
+	}{
+		{
+			"Foo",
+			map[string]string{"legacy": "foo", "snake": "foo", "camel": "foo"},
+		},
+		{
+			"FooBarBaz",
+			map[string]string{"legacy": "foo_bar_baz", "snake": "foo_bar_baz", "camel": "fooBarBaz"},
+		},
+		{
+			"ID",
+			map[string]string{"legacy": "i_d", "snake": "id", "camel": "id"},
+		},
+		{
+			"UserID",
+			map[string]string{"legacy": "user_i_d", "snake": "user_id", "camel": "userID"},
+		},
+		{
+			"HTTPServer",
+			map[string]string{"legacy": "h_t_t_p_server", "snake": "http_server", "camel": "httpServer"},
+		},
+		{
+			"Field2Name",
+			map[string]string{"legacy": "field2_name", "snake": "field2_name", "camel": "field2Name"},
+		},
+	}
+
+	strategies := map[string]FieldNamer{
+		"legacy": LegacySnakeCase,
+		"snake":  SnakeCase,
+		"camel":  LowerCamelCase,
+	}
+
+	for _, tc := range cases {
+		for name, f := range strategies {
+			t.Run(tc.Name+"/"+name, func(t *testing.T) {
+				if actual := f(tc.Name); actual != tc.Expected[name] {
+					t.Fatalf("expected %q, got %q", tc.Expected[name], actual)
+				}
+			})
+		}
+	}
+}
+
+func TestFieldNaming_default(t *testing.T) {
+	v, err := GoToValue(struct{ UserID int }{UserID: 42})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := ValueToGo(v, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if expected := map[string]int64{"user_i_d": 42}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	fields, err := StructFields(reflect.TypeOf(struct{ UserID int }{}), nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(fields) != 1 || fields[0].Key != "user_i_d" {
+		t.Fatalf("bad: %#v", fields)
+	}
+}
+
+func TestGoToValue_sortedMaps(t *testing.T) {
+	source := map[interface{}]interface{}{
+		"b":   1,
+		"a":   2,
+		2:     3,
+		1.5:   4,
+		1:     5,
+		true:  6,
+		false: 7,
+		nil:   8,
+	}
+
+	var first []byte
+	for i := 0; i < 10; i++ {
+		v, err := GoToValue(source)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		var keys []interface{}
+		for _, kv := range v.GetValueMap().GetElems() {
+			key, err := ValueToGo(kv.Key, nil)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			keys = append(keys, key)
+		}
+
+		expected := []interface{}{sdk.Null, false, true, int64(1), 1.5, int64(2), "a", "b"}
+		if !reflect.DeepEqual(keys, expected) {
+			t.Fatalf("bad: %#v", keys)
+		}
+
+		data, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(v)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if first == nil {
+			first = data
+		} else if !bytes.Equal(first, data) {
+			t.Fatal("encoding is not stable")
+		}
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	mustValue := func(v interface{}) *proto.Value {
+		if v, ok := v.(*proto.Value); ok {
+			return v
+		}
+
+		result, err := GoToValue(v)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		return result
+	}
+
+	cases := []struct {
+		Name     string
+		A, B     interface{}
+		Expected int
+	}{
+		{"undefined before null", sdk.Undefined, sdk.Null, -1},
+		{"null before bool", sdk.Null, false, -1},
+		{"bool before number", true, 0, -1},
+		{"number before string", 100, "0", -1},
+		{"string before list", "z", []int{}, -1},
+		{"list before map", []int{1}, map[string]int{}, -1},
+		{"false before true", false, true, -1},
+		{"int and float by value", 2, 1.5, 1},
+		{"int before equal float", 1, 1.0, -1},
+		{"equal ints", 1, 1, 0},
+		{"decimal and int by value", decimalValue("18446744073709551616"), math.MaxInt64, 1},
+		{"decimal and float by value", decimalValue("1.25"), 1.5, -1},
+		{"float before equal decimal", 1.5, decimalValue("1.5"), -1},
+		{"NaN before decimal", math.NaN(), decimalValue("-1e400"), -1},
+		{"equal decimals", decimalValue("1.50"), decimalValue("1.5"), 0},
+		{"strings", "a", "b", -1},
+		{"equal lists", []int{1, 2}, []int{1, 2}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			a, b := mustValue(tc.A), mustValue(tc.B)
+			if actual := CompareValues(a, b); actual != tc.Expected {
+				t.Fatalf("expected %d, got %d", tc.Expected, actual)
+			}
+
+			if actual := CompareValues(b, a); actual != -tc.Expected {
+				t.Fatalf("expected %d reversed, got %d", -tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	type point struct{ X, Y int }
+
+	cases := []struct {
+		Name     string
+		Encoder  Encoder
+		Source   interface{}
+		Expected interface{}
+		Err      bool
+	}{
+		{
+			"zero value",
+			Encoder{},
+			map[string]int{"a": 1},
+			map[string]int64{"a": 1},
+			false,
+		},
+
+		{
+			"nil as null",
+			Encoder{},
+			[]*int{nil},
+			[]interface{}{sdk.Null},
+			false,
+		},
+
+		{
+			"nil as undefined",
+			Encoder{NilAsUndefined: true},
+			[]*int{nil},
+			[]interface{}{sdk.Undefined},
+			false,
+		},
+
+		{
+			"json marshaler",
+			Encoder{FallbackMarshalers: true},
+			testJSONMarshaler{Name: "foo", Count: 2},
+			[]interface{}{"foo", int64(2)},
+			false,
+		},
+
+		{
+			"text marshaler",
+			Encoder{FallbackMarshalers: true},
+			testTextMarshaler{Value: "foo"},
+			"<foo>",
+			false,
+		},
+
+		{
+			"sentinel marshaler before fallbacks",
+			Encoder{FallbackMarshalers: true},
+			testSentinelMarshaler{A: 1, B: 2},
+			"1-2",
+			false,
+		},
+
+		{
+			"field naming",
+			Encoder{FieldNaming: LowerCamelCase},
+			struct{ UserID int }{42},
+			map[string]int64{"userID": 42},
+			false,
+		},
+
+		{
+			"uint overflow",
+			Encoder{},
+			uint64(1 << 63),
+			int64(-1 << 63),
+			false,
+		},
+
+		{
+			"uint overflow strict",
+			Encoder{Strict: true},
+			uint64(1 << 63),
+			nil,
+			true,
+		},
+
+		{
+			"bytes as list",
+			Encoder{},
+			[]byte("hi"),
+			[]int64{104, 105},
+			false,
+		},
+
+		{
+			"bytes as base64",
+			Encoder{Base64Bytes: true},
+			[]byte("hello"),
+			"aGVsbG8=",
+			false,
+		},
+
+		{
+			"nil bytes as base64",
+			Encoder{Base64Bytes: true},
+			map[string][]byte{"a": nil},
+			map[string]interface{}{"a": sdk.Null},
+			false,
+		},
+
+		{
+			"max depth",
+			Encoder{MaxDepth: 2},
+			[][]int{{1}},
+			[]interface{}{[]int64{1}},
+			false,
+		},
+
+		{
+			"max depth exceeded",
+			Encoder{MaxDepth: 2},
+			[][][]int{{{1}}},
+			nil,
+			true,
+		},
+
+		{
+			"max depth exceeded struct",
+			Encoder{MaxDepth: 1},
+			[]point{{1, 2}},
+			nil,
+			true,
+		},
+
+		{
+			"max size",
+			Encoder{MaxSize: 3},
+			[][]int{{1, 2}},
+			[]interface{}{[]int64{1, 2}},
+			false,
+		},
+
+		{
+			"max size exceeded",
+			Encoder{MaxSize: 3},
+			[][]int{{1, 2, 3}},
+			nil,
+			true,
+		},
+
+		{
+			"max size exceeded struct",
+			Encoder{MaxSize: 1},
+			point{1, 2},
+			nil,
+			true,
+		},
+
+		{
+			"hook",
+			Encoder{
+				Hooks: map[reflect.Type]func(interface{}) (interface{}, error){
+					reflect.TypeOf(point{}): func(v interface{}) (interface{}, error) {
+						p := v.(point)
+						return []int{p.X, p.Y}, nil
+					},
+				},
+			},
+			[]point{{1, 2}},
+			[]interface{}{[]int64{1, 2}},
+			false,
+		},
+
+		{
+			"hook primitive",
+			Encoder{
+				Hooks: map[reflect.Type]func(interface{}) (interface{}, error){
+					reflect.TypeOf(""): func(v interface{}) (interface{}, error) {
+						return len(v.(string)), nil
+					},
+				},
+			},
+			[]interface{}{"foo", 1},
+			[]int64{3, 1},
+			false,
+		},
+
+		{
+			"hook error",
+			Encoder{
+				Hooks: map[reflect.Type]func(interface{}) (interface{}, error){
+					reflect.TypeOf(point{}): func(v interface{}) (interface{}, error) {
+						return nil, fmt.Errorf("nope")
+					},
+				},
+			},
+			point{},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			value, err := tc.Encoder.Encode(tc.Source)
+			if (err != nil) != tc.Err {
+				t.Fatalf("err: %s", err)
+			}
+			if err != nil {
+				return
+			}
+
+			actual, err := ValueToGo(value, nil)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("bad: %#v", actual)
+			}
+		})
+	}
+}
+
+func TestEncoder_unsortedMaps(t *testing.T) {
+	// With sorting disabled, the keys are in random order. Converting
+	// enough keys makes it very unlikely that they come out sorted by
+	// chance every time.
+	m := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		m[i] = true
+	}
+
+	e := Encoder{UnsortedMaps: true}
+	for i := 0; i < 10; i++ {
+		v, err := e.Encode(m)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		elems := v.GetValueMap().GetElems()
+		for j := range elems {
+			if elems[j].Key.GetValueInt() != int64(j) {
+				return
+			}
+		}
+	}
+
+	t.Fatal("keys should not be sorted")
+}
+
+func TestDecoder(t *testing.T) {
+	type point struct {
+		X int
+		Y int `sentinel:"y_pos"`
+	}
+
+	type embedded struct {
+		*TestEmbedded `sentinel:",inline"`
+		Name          string
+	}
+
+	type embeddedUnexported struct {
+		*point `sentinel:",inline"`
+	}
+
+	type tagged struct {
+		Count int  `sentinel:",string"`
+		On    bool `sentinel:",string"`
+		Skip  int  `sentinel:"-"`
+	}
+
+	cases := []struct {
+		Name     string
+		Decoder  Decoder
+		Source   interface{}
+		Expected interface{}
+		Err      bool
+	}{
+		{
+			"struct",
+			Decoder{},
+			map[string]interface{}{"x": 1, "y_pos": 2, "z": 3},
+			point{X: 1, Y: 2},
+			false,
+		},
+
+		{
+			"json unmarshaler",
+			Decoder{FallbackUnmarshalers: true},
+			[]interface{}{"foo", 2},
+			testJSONMarshaler{Name: "foo", Count: 2},
+			false,
+		},
+
+		{
+			"text unmarshaler",
+			Decoder{FallbackUnmarshalers: true},
+			"<foo>",
+			testTextMarshaler{Value: "foo"},
+			false,
+		},
+
+		{
+			"struct unknown field",
+			Decoder{DisallowUnknownFields: true},
+			map[string]interface{}{"x": 1, "y_pos": 2, "z": 3},
+			point{},
+			true,
+		},
+
+		{
+			"struct non-string key",
+			Decoder{DisallowUnknownFields: true},
+			map[int]int{1: 2},
+			point{},
+			true,
+		},
+
+		{
+			"struct field naming",
+			Decoder{FieldNaming: GoFieldName},
+			map[string]interface{}{"X": 1},
+			point{X: 1},
+			false,
+		},
+
+		{
+			"struct inline",
+			Decoder{},
+			map[string]interface{}{"bar": 1, "name": "foo"},
+			embedded{TestEmbedded: &TestEmbedded{Bar: 1}, Name: "foo"},
+			false,
+		},
+
+		{
+			"struct inline unexported pointer",
+			Decoder{},
+			map[string]interface{}{"x": 1},
+			embeddedUnexported{},
+			true,
+		},
+
+		{
+			"struct null field",
+			Decoder{},
+			map[string]interface{}{"point": sdk.Null},
+			struct{ Point *point }{},
+			false,
+		},
+
+		{
+			"struct string option",
+			Decoder{},
+			map[string]interface{}{"count": "42", "on": "true", "skip": 1},
+			tagged{Count: 42, On: true},
+			false,
+		},
+
+		{
+			"struct string option strict",
+			Decoder{Strict: true},
+			map[string]interface{}{"count": "42"},
+			tagged{Count: 42},
+			false,
+		},
+
+		{
+			"int overflow",
+			Decoder{},
+			300,
+			int8(44),
+			false,
+		},
+
+		{
+			"int overflow strict",
+			Decoder{Strict: true},
+			300,
+			int8(0),
+			true,
+		},
+
+		{
+			"uint overflow strict",
+			Decoder{Strict: true},
+			300,
+			uint8(0),
+			true,
+		},
+
+		{
+			"string to int strict",
+			Decoder{Strict: true},
+			"42",
+			int64(0),
+			true,
+		},
+
+		{
+			"int to string strict",
+			Decoder{Strict: true},
+			42,
+			"",
+			true,
+		},
+
+		{
+			"int to float",
+			Decoder{Strict: true},
+			1 << 53,
+			float64(1 << 53),
+			false,
+		},
+
+		{
+			"inexact int to float strict",
+			Decoder{Strict: true},
+			1<<53 + 1,
+			float64(0),
+			true,
+		},
+
+		{
+			"float to float32",
+			Decoder{Strict: true},
+			0.5,
+			float32(0.5),
+			false,
+		},
+
+		{
+			"inexact float to float32 strict",
+			Decoder{Strict: true},
+			0.1,
+			float32(0),
+			true,
+		},
+
+		{
+			"max depth exceeded",
+			Decoder{MaxDepth: 1},
+			[][]int{{1}},
+			[][]int{},
+			true,
+		},
+
+		{
+			"max size exceeded",
+			Decoder{MaxSize: 2},
+			map[string]interface{}{"x": 1, "y_pos": 2, "z": 3},
+			point{},
+			true,
+		},
+
+		{
+			"hook",
+			Decoder{
+				Hooks: map[reflect.Type]func(*proto.Value) (interface{}, error){
+					reflect.TypeOf(point{}): func(v *proto.Value) (interface{}, error) {
+						elems := v.GetValueList().GetElems()
+						return point{
+							X: int(elems[0].GetValueInt()),
+							Y: int(elems[1].GetValueInt()),
+						}, nil
+					},
+				},
+			},
+			[]int{1, 2},
+			point{X: 1, Y: 2},
+			false,
+		},
+
+		{
+			"hook wrong type",
+			Decoder{
+				Hooks: map[reflect.Type]func(*proto.Value) (interface{}, error){
+					reflect.TypeOf(point{}): func(v *proto.Value) (interface{}, error) {
+						return 42, nil
+					},
+				},
+			},
+			[]int{1, 2},
+			point{},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			value, err := GoToValue(tc.Source)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			actual, err := tc.Decoder.Decode(value, reflect.TypeOf(tc.Expected))
+			if (err != nil) != tc.Err {
+				t.Fatalf("err: %s", err)
+			}
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("bad: %#v", actual)
+			}
+		})
+	}
+}
+
+func TestGoToValue_cycle(t *testing.T) {
+	type node struct {
+		Name     string
+		Children []*node
+		Attrs    map[string]interface{}
+	}
+
+	type inlined struct {
+		*inlined `sentinel:",inline"`
+		X        int
+	}
+
+	shared := &node{Name: "shared"}
+	cyclicList := make([]interface{}, 1)
+	cyclicList[0] = cyclicList
+
+	cases := []struct {
+		Name   string
+		Source func() interface{}
+		Err    string
+	}{
+		{
+			"shared pointer",
+			func() interface{} {
+				return &node{Children: []*node{shared, shared}}
+			},
+			"",
+		},
+
+		{
+			"pointer",
+			func() interface{} {
+				n := &node{Name: "root"}
+				n.Children = []*node{{Name: "child"}, n}
+				return n
+			},
+			"cycle detected at value.children[1]",
+		},
+
+		{
+			"map",
+			func() interface{} {
+				n := &node{Attrs: map[string]interface{}{}}
+				n.Attrs["my key"] = n.Attrs
+				return n
+			},
+			`cycle detected at value.attrs["my key"]`,
+		},
+
+		{
+			"slice",
+			func() interface{} { return cyclicList },
+			"cycle detected at value[0]",
+		},
+
+		{
+			"max depth",
+			func() interface{} {
+				return &node{Children: []*node{{Children: []*node{{}}}}}
+			},
+			"maximum depth of 4 exceeded at value.children[0].children[0]",
+		},
+
+		{
+			"inlined pointer",
+			func() interface{} {
+				v := &inlined{X: 1}
+				v.inlined = v
+				return v
+			},
+			"cycle detected at value",
+		},
+
+		{
+			"inlined max depth",
+			func() interface{} {
+				v := &inlined{}
+				for i := 0; i < 4; i++ {
+					v = &inlined{inlined: v}
+				}
+
+				return v
+			},
+			"maximum depth of 4 exceeded at value",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			e := Encoder{MaxDepth: 4}
+			_, err := e.Encode(tc.Source())
+			if tc.Err == "" && err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if tc.Err != "" && (err == nil || err.Error() != tc.Err) {
+				t.Fatalf("expected error %q, got %v", tc.Err, err)
+			}
+		})
+	}
+}
+
+// testSelfMarshaler returns a value of its own type from MarshalSentinel,
+// which marshals again without end.
+type testSelfMarshaler struct{ N int }
+
+func (m testSelfMarshaler) MarshalSentinel() (interface{}, error) {
+	return testSelfMarshaler{N: m.N + 1}, nil
+}
+
+// testPtrMarshaler returns itself from MarshalSentinel.
+type testPtrMarshaler struct{}
+
+func (m *testPtrMarshaler) MarshalSentinel() (interface{}, error) {
+	return m, nil
+}
+
+func TestGoToValue_marshalerCycle(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Encoder Encoder
+		Source  interface{}
+		Err     string
+	}{
+		{
+			"own type",
+			Encoder{MaxDepth: 4},
+			testSelfMarshaler{},
+			"maximum depth of 4 exceeded at value",
+		},
+
+		{
+			"own type without max depth",
+			Encoder{},
+			testSelfMarshaler{},
+			"maximum depth of 1000 marshaler calls exceeded at value",
+		},
+
+		{
+			"itself",
+			Encoder{},
+			&testPtrMarshaler{},
+			"cycle detected at value",
+		},
+
+		{
+			"itself in list",
+			Encoder{},
+			[]interface{}{&testPtrMarshaler{}},
+			"cycle detected at value[0]",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := tc.Encoder.Encode(tc.Source)
+			if err == nil || err.Error() != tc.Err {
+				t.Fatalf("expected error %q, got %v", tc.Err, err)
+			}
+		})
+	}
+}
+
+func TestValueToGo_inlineCycle(t *testing.T) {
+	type inlined struct {
+		*inlined `sentinel:",inline"`
+		X        int
+	}
+
+	v, err := GoToValue(map[string]interface{}{"x": 1})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := ValueToGo(v, reflect.TypeOf(inlined{}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if expected := (inlined{X: 1}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+// benchmarkPayloads are representative plugin results for benchmarks:
+// small objects, and large documents as decoded from JSON or built from
+// structs, such as a list of cloud resources.
+var benchmarkPayloads = func() map[string]interface{} {
+	type tag struct {
+		Key   string
+		Value string
+	}
+
+	type resource struct {
+		ID      string
+		Name    string
+		Size    int
+		Cost    float64
+		Enabled bool
+		Tags    []tag
+		Labels  map[string]string
+	}
+
+	resourceMap := func(i int) map[string]interface{} {
+		return map[string]interface{}{
+			"id":      fmt.Sprintf("r-%d", i),
+			"name":    fmt.Sprintf("resource %d", i),
+			"size":    int64(i),
+			"cost":    float64(i) * 1.5,
+			"enabled": i%2 == 0,
+			"tags": []interface{}{
+				map[string]interface{}{"key": "env", "value": "prod"},
+				map[string]interface{}{"key": "team", "value": "core"},
+			},
+		}
+	}
+
+	documents := make([]interface{}, 10000)
+	structs := make([]resource, 10000)
+	for i := range documents {
+		documents[i] = resourceMap(i)
+		structs[i] = resource{
+			ID:      fmt.Sprintf("r-%d", i),
+			Name:    fmt.Sprintf("resource %d", i),
+			Size:    i,
+			Cost:    float64(i) * 1.5,
+			Enabled: i%2 == 0,
+			Tags:    []tag{{"env", "prod"}, {"team", "core"}},
+			Labels:  map[string]string{"env": "prod", "team": "core"},
+		}
+	}
+
+	return map[string]interface{}{
+		"small":     resourceMap(0),
+		"documents": map[string]interface{}{"resources": documents},
+		"structs":   structs,
+	}
+}()
+
+func BenchmarkGoToValue(b *testing.B) {
+	for _, name := range []string{"small", "documents", "structs"} {
+		b.Run(name, func(b *testing.B) {
+			payload := benchmarkPayloads[name]
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := GoToValue(payload); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkValueToGo(b *testing.B) {
+	for _, name := range []string{"small", "documents", "structs"} {
+		b.Run(name, func(b *testing.B) {
+			value, err := GoToValue(benchmarkPayloads[name])
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := ValueToGo(value, nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestDecimal_encode(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Source   interface{}
+		Expected *proto.Value
+	}{
+		{"uint64 overflow", uint64(math.MaxUint64), decimalValue("18446744073709551615")},
+		{"uint64 fits", uint64(42), intValue(42)},
+		{"big int", *mustParseBigInt("123456789012345678901234567890"), decimalValue("123456789012345678901234567890")},
+		{"big int pointer", big.NewInt(42), decimalValue("42")},
+		{"big float", *big.NewFloat(1.5), decimalValue("1.5")},
+		{"big float infinity", *big.NewFloat(math.Inf(1)), floatValue(math.Inf(1))},
+		{"big rat", big.NewRat(-1, 80), decimalValue("-0.0125")},
+		{"big rat integer", big.NewRat(4, 2), decimalValue("2")},
+		{"big rat repeating", big.NewRat(1, 3), stringValue("1/3")},
+	}
+
+	enc := &Encoder{Decimals: true}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := enc.Encode(tc.Source)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !protobuf.Equal(actual, tc.Expected) {
+				t.Fatalf("expected %s, got %s", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestDecimal_decode(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Type     reflect.Type
+		Strict   bool
+		Expected interface{}
+		Err      string
+	}{
+		{"interface integer", "18446744073709551616", nil, false, *mustParseBigInt("18446744073709551616"), ""},
+		{"interface fraction", "1.5", nil, false, *big.NewRat(3, 2), ""},
+		{"interface exponent", "-1.25e-2", nil, false, *big.NewRat(-1, 80), ""},
+		{"uint64", "18446744073709551615", reflect.TypeOf(uint64(0)), false, uint64(math.MaxUint64), ""},
+		{"uint64 overflow", "18446744073709551616", reflect.TypeOf(uint64(0)), false, nil, "overflows uint64"},
+		{"uint64 negative", "-1", reflect.TypeOf(uint64(0)), false, nil, "overflows uint64"},
+		{"int64 overflow", "9223372036854775808", reflect.TypeOf(int64(0)), false, nil, "overflows int64"},
+		{"int fraction", "1.5", reflect.TypeOf(0), false, nil, "fractional part"},
+		{"int exponent", "1e3", reflect.TypeOf(0), false, 1000, ""},
+		{"float", "0.1", reflect.TypeOf(float64(0)), false, 0.1, ""},
+		{"float strict", "0.1", reflect.TypeOf(float64(0)), true, nil, "cannot be represented exactly"},
+		{"float strict exact", "0.5", reflect.TypeOf(float64(0)), true, 0.5, ""},
+		{"string", "1.5", reflect.TypeOf(""), false, "1.5", ""},
+		{"string strict", "1.5", reflect.TypeOf(""), true, nil, "cannot convert to string"},
+		{"big int", "-123456789012345678901234567890", bigIntTyp, false, *mustParseBigInt("-123456789012345678901234567890"), ""},
+		{"big float", "2.5", bigFloatTyp, false, *big.NewFloat(2.5).SetPrec(64), ""},
+		{"big rat", "0.1", bigRatTyp, false, *big.NewRat(1, 10), ""},
+		{"invalid", "abc", nil, false, nil, "invalid decimal"},
+		{"infinity", "Inf", nil, false, nil, "invalid decimal"},
+		{"fraction", "1/3", nil, false, nil, "invalid decimal"},
+		{"hex", "0x10", nil, false, nil, "invalid decimal"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dec := &Decoder{Strict: tc.Strict}
+			actual, err := dec.Decode(decimalValue(tc.Value), tc.Type)
+			if tc.Err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Err) {
+					t.Fatalf("expected error containing %q, got: %v", tc.Err, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			// big.Float and big.Rat can't be compared with DeepEqual
+			switch expected := tc.Expected.(type) {
+			case big.Float:
+				f, ok := actual.(big.Float)
+				if !ok || f.Cmp(&expected) != 0 {
+					t.Fatalf("expected %v, got %#v", &expected, actual)
+				}
+
+				return
+
+			case big.Rat:
+				r, ok := actual.(big.Rat)
+				if !ok || r.Cmp(&expected) != 0 {
+					t.Fatalf("expected %v, got %#v", &expected, actual)
+				}
+
+				return
+			}
+
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestDecimal_roundTrip(t *testing.T) {
+	for _, s := range []string{"0", "-18446744073709551616", "1.5", "-1.25e-40", "123456789.123456789", "0.1"} {
+		t.Run(s, func(t *testing.T) {
+			v := decimalValue(s)
+			raw, err := ValueToGo(v, nil)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			actual, err := (&Encoder{Decimals: true}).Encode(raw)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			// Decimals are equal by value rather than by their string
+			if actual.Type != proto.Value_DECIMAL || CompareValues(actual, v) != 0 {
+				t.Fatalf("expected %s, got %s", v, actual)
+			}
+		})
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	type inner struct {
+		B int
+		C int `sentinel:"a"`
+	}
+
+	type recursive struct {
+		D          int
+		*recursive `sentinel:",inline"`
+	}
+
+	cases := []struct {
+		Name     string
+		Type     reflect.Type
+		Expected []StructField
+		Err      string
+	}{
+		{
+			"tags",
+			reflect.TypeOf(struct {
+				A int `sentinel:"x,omitempty"`
+				B int `sentinel:",string"`
+				C int `sentinel:"-"`
+				d int
+				E int `sentinel:",memo=lazy"`
+			}{}),
+			[]StructField{
+				{Key: "x", Index: []int{0}, OmitEmpty: true},
+				{Key: "b", Index: []int{1}, AsString: true},
+				{Key: "e", Index: []int{4}, Memo: "lazy"},
+			},
+			"",
+		},
+
+		{
+			"inline shadowed",
+			reflect.TypeOf(struct {
+				A     int
+				Inner *inner `sentinel:",inline"`
+			}{}),
+			[]StructField{
+				{Key: "a", Index: []int{0}},
+				{Key: "b", Index: []int{1, 0}},
+			},
+			"",
+		},
+
+		{
+			"inline recursive",
+			reflect.TypeOf(recursive{}),
+			[]StructField{
+				{Key: "d", Index: []int{0}},
+			},
+			"",
+		},
+
+		{
+			"inline non-struct",
+			reflect.TypeOf(struct {
+				A int `sentinel:",inline"`
+			}{}),
+			nil,
+			"only structs can be inlined",
+		},
+
+		{
+			"not a struct",
+			reflect.TypeOf(42),
+			nil,
+			"expected struct",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := StructFields(tc.Type, nil)
+			if tc.Err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Err) {
+					t.Fatalf("expected error containing %q, got: %v", tc.Err, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestHasCustomConversion(t *testing.T) {
+	cases := []struct {
+		Value    interface{}
+		Expected bool
+	}{
+		{time.Time{}, true},
+		{&time.Time{}, true},
+		{url.URL{}, true},
+		{big.NewInt(1), true},
+		{struct{ A int }{}, false},
+		{42, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%T", tc.Value), func(t *testing.T) {
+			if actual := HasCustomConversion(reflect.TypeOf(tc.Value)); actual != tc.Expected {
+				t.Fatalf("expected %v, got %v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestValueToJSON(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Source   interface{}
+		Expected string
+		Err      bool
+	}{
+		{"null", sdk.Null, `null`, false},
+		{"undefined", sdk.Undefined, `null`, false},
+		{"int", 42, `42`, false},
+		{"float", 1.5, `1.5`, false},
+		{"string", "foo", `"foo"`, false},
+		{"list", []interface{}{1, sdk.Undefined, sdk.Null}, `[1,null,null]`, false},
+		{
+			"map",
+			map[string]interface{}{"b": 1, "a": sdk.Undefined, "c": sdk.Null},
+			`{"b":1,"c":null}`,
+			false,
+		},
+		{"map non-string keys", map[int]bool{1: true}, `{"1":true}`, false},
+		{"map list keys", map[[1]int]bool{{1}: true}, ``, true},
+		{
+			"struct undefined field",
+			struct {
+				A interface{}
+				B int
+			}{sdk.Undefined, 2},
+			`{"b":2}`,
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := MarshalJSON(tc.Source)
+			if (err != nil) != tc.Err {
+				t.Fatalf("err: %s", err)
+			}
+
+			if string(actual) != tc.Expected {
+				t.Fatalf("expected %s, got %s", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestJSONToValue(t *testing.T) {
+	cases := []struct {
+		Name     string
+		JSON     string
+		Expected interface{}
+		Err      bool
+	}{
+		{"int", `42`, int64(42), false},
+		{"float", `42.5`, 42.5, false},
+		{"float exponent", `1e3`, float64(1000), false},
+		{"large int", `18446744073709551616`, float64(1 << 64), false},
+		{"null", `null`, sdk.Null, false},
+		{
+			"object",
+			`{"a": [1, "b"], "c": {"d": true}}`,
+			map[string]interface{}{
+				"a": []interface{}{int64(1), "b"},
+				"c": map[string]bool{"d": true},
+			},
+			false,
+		},
+		{"invalid", `{`, nil, true},
+		{"trailing data", `1 2`, nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			v, err := JSONToValue([]byte(tc.JSON))
+			if (err != nil) != tc.Err {
+				t.Fatalf("err: %s", err)
+			}
+			if err != nil {
+				return
+			}
+
+			actual, err := ValueToGo(v, nil)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("bad: %#v", actual)
+			}
+		})
+	}
+}
+
+func TestTaggedJSON(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Source interface{}
+		JSON   string
+	}{
+		{"undefined", sdk.Undefined, `{"undefined":true}`},
+		{"null", sdk.Null, `{"null":true}`},
+		{"bool", true, `{"bool":true}`},
+		{"int", int64(1<<62 + 1), `{"int":"4611686018427387905"}`},
+		{"float", 1.0, `{"float":1}`},
+		{"float NaN", math.NaN(), `{"float":"NaN"}`},
+		{"float infinity", math.Inf(-1), `{"float":"-Infinity"}`},
+		{"decimal", decimalValue("18446744073709551616"), `{"decimal":"18446744073709551616"}`},
+		{"string", "foo", `{"string":"foo"}`},
+		{
+			"list",
+			[]interface{}{1, sdk.Undefined},
+			`{"list":[{"int":"1"},{"undefined":true}]}`,
+		},
+		{
+			"map",
+			map[interface{}]interface{}{2: "b", "a": 1.5},
+			`{"map":[{"key":{"int":"2"},"value":{"string":"b"}},{"key":{"string":"a"},"value":{"float":1.5}}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			v, ok := tc.Source.(*proto.Value)
+			if !ok {
+				var err error
+				v, err = GoToValue(tc.Source)
+				if err != nil {
+					t.Fatalf("err: %s", err)
+				}
+			}
+
+			data, err := ValueToTaggedJSON(v)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if string(data) != tc.JSON {
+				t.Fatalf("expected %s, got %s", tc.JSON, data)
+			}
+
+			actual, err := TaggedJSONToValue(data)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			// NaN is never equal to itself, so compare the encoded forms
+			if !protobuf.Equal(actual, v) && !bytes.Equal(mustTaggedJSON(t, actual), data) {
+				t.Fatalf("bad: %s", actual)
+			}
+		})
+	}
+}
+
+func TestTaggedJSONToValue_invalid(t *testing.T) {
+	cases := []string{
+		`{}`,
+		`{"int":"1","float":1}`,
+		`{"int":1}`,
+		`{"float":"nope"}`,
+		`{"map":[{"key":{"int":"1"}}]}`,
+		`{"list":[{"nope":true}]}`,
+		`[]`,
+	}
+
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			if _, err := TaggedJSONToValue([]byte(tc)); err == nil {
+				t.Fatal("should error")
+			}
+		})
+	}
+}
+
+func mustTaggedJSON(t *testing.T, v *proto.Value) []byte {
+	t.Helper()
+
+	data, err := ValueToTaggedJSON(v)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return data
+}
+
+func TestRoundTrip(t *testing.T) {
+	f := func(v quickValue) bool {
+		raw, err := ValueToGo(v.Value, nil)
+		if err != nil {
+			t.Logf("ValueToGo(%s): %s", v.Value, err)
+			return false
+		}
+
+		actual, err := GoToValue(raw)
+		if err != nil {
+			t.Logf("GoToValue(%#v): %s", raw, err)
+			return false
+		}
+
+		if CompareValues(actual, v.Value) != 0 {
+			t.Logf("\nexpected: %s\nactual:   %s", v.Value, actual)
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(f, &quick.Config{MaxCount: 1000}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValueToGo_mapKeys(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    *proto.Value
+		Type     reflect.Type
+		Expected interface{}
+		Err      string
+	}{
+		{
+			"int keys",
+			mapValue(intValue(1), stringValue("a")),
+			nil,
+			map[int64]string{1: "a"},
+			"",
+		},
+
+		{
+			"mixed keys",
+			mapValue(intValue(1), stringValue("a"), floatValue(1), stringValue("b")),
+			nil,
+			map[interface{}]string{int64(1): "a", float64(1): "b"},
+			"",
+		},
+
+		{
+			"empty typed map",
+			mapValue(),
+			reflect.TypeOf(map[int]string{}),
+			map[int]string{},
+			"",
+		},
+
+		{
+			"empty untyped map",
+			mapValue(),
+			nil,
+			map[string]interface{}{},
+			"",
+		},
+
+		{
+			"list key",
+			mapValue(listValue(intValue(1)), stringValue("a")),
+			nil,
+			nil,
+			"cannot use LIST as a map key",
+		},
+
+		{
+			"map key",
+			mapValue(mapValue(), stringValue("a"), intValue(1), stringValue("b")),
+			nil,
+			nil,
+			"cannot use MAP as a map key",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := ValueToGo(tc.Value, tc.Type)
+			if tc.Err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Err) {
+					t.Fatalf("expected error containing %q, got: %v", tc.Err, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+// quickValue is a random value for testing/quick.
+type quickValue struct {
+	*proto.Value
+}
+
+func (quickValue) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(quickValue{randomValue(r, 3)})
+}
+
+// randomValue returns a random value of any type, nesting lists and maps
+// up to depth levels. Maps have unique scalar keys in sorted order, since
+// those are the maps that convert to Go maps and back unchanged.
+func randomValue(r *rand.Rand, depth int) *proto.Value {
+	n := 7
+	if depth <= 0 {
+		n = 5
+	}
+
+	switch r.Intn(n) {
+	case 0:
+		return &proto.Value{Type: proto.Value_UNDEFINED}
+
+	case 1:
+		return &proto.Value{Type: proto.Value_NULL}
+
+	case 2, 3, 4:
+		return randomScalar(r)
+
+	case 5:
+		elems := make([]*proto.Value, r.Intn(4))
+		for i := range elems {
+			elems[i] = randomValue(r, depth-1)
+		}
+
+		return listValue(elems...)
+
+	default:
+		var elems []*proto.Value_KV
+		for i := r.Intn(4); i > 0; i-- {
+			key := randomScalar(r)
+			unique := true
+			for _, elem := range elems {
+				if CompareValues(elem.Key, key) == 0 {
+					unique = false
+				}
+			}
+
+			if unique {
+				elems = append(elems, &proto.Value_KV{
+					Key:   key,
+					Value: randomValue(r, depth-1),
+				})
+			}
+		}
+		sort.Sort(kvByKey(elems))
+
+		return &proto.Value{
+			Type: proto.Value_MAP,
+			Value: &proto.Value_ValueMap{
+				ValueMap: &proto.Value_Map{Elems: elems},
+			},
+		}
+	}
+}
+
+// randomScalar returns a random bool, int, float or string. Values are
+// drawn from small sets so that map keys and special values such as NaN
+// occur often.
+func randomScalar(r *rand.Rand) *proto.Value {
+	switch r.Intn(4) {
+	case 0:
+		return boolValue(r.Intn(2) == 0)
+
+	case 1:
+		ints := []int64{0, 1, -1, 42, math.MaxInt64, math.MinInt64}
+		return intValue(ints[r.Intn(len(ints))])
+
+	case 2:
+		floats := []float64{0, 1, -1.5, math.MaxFloat64, math.Inf(1), math.NaN()}
+		return floatValue(floats[r.Intn(len(floats))])
+
+	default:
+		strs := []string{"", "a", "b", "1", "héllo"}
+		return stringValue(strs[r.Intn(len(strs))])
+	}
+}
+
+func boolValue(v bool) *proto.Value {
+	return &proto.Value{
+		Type:  proto.Value_BOOL,
+		Value: &proto.Value_ValueBool{ValueBool: v},
+	}
+}
+
+func listValue(elems ...*proto.Value) *proto.Value {
+	return &proto.Value{
+		Type: proto.Value_LIST,
+		Value: &proto.Value_ValueList{
+			ValueList: &proto.Value_List{Elems: elems},
+		},
+	}
+}
+
+// mapValue returns a map of alternating keys and values.
+func mapValue(kvs ...*proto.Value) *proto.Value {
+	elems := make([]*proto.Value_KV, 0, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		elems = append(elems, &proto.Value_KV{Key: kvs[i], Value: kvs[i+1]})
+	}
+
+	return &proto.Value{
+		Type: proto.Value_MAP,
+		Value: &proto.Value_ValueMap{
+			ValueMap: &proto.Value_Map{Elems: elems},
+		},
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FieldNamer converts the name of an exported struct field to the key it
+// is given in the resulting Sentinel map. Fields with a name set in their
+// "sentinel" struct tag are not affected.
+type FieldNamer func(string) string
+
+// name converts name with f, or LegacySnakeCase if f is nil, which is the
+// default for backwards compatibility.
+func (f FieldNamer) name(name string) string {
+	if f == nil {
+		return LegacySnakeCase(name)
+	}
+
+	return f(name)
+}
+
+// LegacySnakeCase lower and snake cases the field name, treating every
+// upper case letter as the start of a new word. For example, "FooBar"
+// becomes "foo_bar", while "UserID" becomes "user_i_d".
+func LegacySnakeCase(name string) string {
+	var result []string
+	var last int
+	s := []rune(name)
+
+	// Always convert the zero-index rune to a lowercase letter. Since we always
+	// operate on exported struct fields, this is fine and is actually less
+	// costly than doing an IsUpper first.
+	s[0] = unicode.ToLower(s[0])
+
+	for idx := 1; idx < len(s); idx++ {
+		if unicode.IsUpper(s[idx]) {
+			result = append(result, string(s[last:idx]))
+			last = idx
+			s[idx] = unicode.ToLower(s[idx])
+		}
+	}
+
+	// Append anything remaining
+	result = append(result, string(s[last:]))
+
+	return strings.Join(result, "_")
+}
+
+// SnakeCase lower and snake cases the field name, keeping acronyms
+// together as a single word. For example, "UserID" becomes "user_id" and
+// "HTTPServer" becomes "http_server".
+func SnakeCase(name string) string {
+	return strings.Join(fieldWords(name), "_")
+}
+
+// LowerCamelCase lower cases the first word of the field name, keeping
+// acronyms together. For example, "UserID" becomes "userID" and
+// "HTTPServer" becomes "httpServer".
+func LowerCamelCase(name string) string {
+	words := fieldWords(name)
+	s := []rune(name)
+	n := len([]rune(words[0]))
+	return words[0] + string(s[n:])
+}
+
+// GoFieldName uses the field name unaltered.
+func GoFieldName(name string) string {
+	return name
+}
+
+// fieldWords splits a field name into lower case words. A new word starts
+// at an upper case letter following a lower case letter or digit, and at
+// the last upper case letter of an acronym followed by a lower case letter.
+func fieldWords(name string) []string {
+	s := []rune(name)
+
+	var result []string
+	var last int
+	for idx := 1; idx < len(s); idx++ {
+		if !unicode.IsUpper(s[idx]) {
+			continue
+		}
+
+		prev := s[idx-1]
+		nextLower := idx+1 < len(s) && unicode.IsLower(s[idx+1])
+		if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+			result = append(result, strings.ToLower(string(s[last:idx])))
+			last = idx
+		}
+	}
+
+	return append(result, strings.ToLower(string(s[last:])))
+}
@@ -6,14 +6,22 @@ package encoding
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
 	"strings"
-	"unicode"
 
 	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/internal/tagopt"
+	"github.com/hashicorp/sentinel-sdk/internal/walk"
 	proto "github.com/hashicorp/sentinel-sdk/proto/go"
 )
 
+var (
+	nullTyp      = reflect.TypeOf(sdk.Null)
+	undefinedTyp = reflect.TypeOf(sdk.Undefined)
+)
+
 // GoToValue converts the Go value to a protobuf Object.
 //
 // The Go value must contain only primitives, collections of primitives,
@@ -22,14 +30,106 @@ import (
 //
 // The primitive types byte and rune are aliases to integer types (as
 // defined by the Go spec) and are treated as integers in conversion.
+//
+// A number of well-known types from the standard library, such as
+// time.Time, are converted to their natural Sentinel representation. Other
+// types can control their conversion by implementing SentinelMarshaler.
+// See the package documentation for details.
+//
+// Values that refer back to themselves through pointers, maps or slices
+// can't be represented and return an error naming the path of the cycle.
+//
+// To convert with options other than the defaults, use an Encoder.
 func GoToValue(raw interface{}) (*proto.Value, error) {
-	return toValue_reflect(reflect.ValueOf(raw))
+	return defaultEncoder.Encode(raw)
+}
+
+// toValue converts raw, using fast paths that avoid reflection for the
+// types plugins most commonly return, such as data decoded from JSON.
+func (s *encodeState) toValue(raw interface{}) (*proto.Value, error) {
+	// Hooks can apply to any type, including these
+	if s.Hooks == nil {
+		switch x := raw.(type) {
+		case nil:
+			return s.nilValue(), nil
+
+		case bool:
+			return s.alloc.boolValue(x), nil
+
+		case int:
+			return s.alloc.intValue(int64(x)), nil
+
+		case int64:
+			return s.alloc.intValue(x), nil
+
+		case float64:
+			return s.alloc.floatValue(x), nil
+
+		case string:
+			return s.alloc.stringValue(x), nil
+
+		case []interface{}:
+			return s.toValue_interfaceSlice(raw, x)
+
+		case map[string]interface{}:
+			return s.toValue_stringMap(raw, x)
+		}
+	}
+
+	return s.toValue_reflect(reflect.ValueOf(raw))
 }
 
-func toValue_reflect(v reflect.Value) (*proto.Value, error) {
+func (s *encodeState) toValue_reflect(v reflect.Value) (*proto.Value, error) {
 	// Null pointer
 	if !v.IsValid() {
-		return &proto.Value{Type: proto.Value_NULL}, nil
+		return s.nilValue(), nil
+	}
+
+	// Hooks take precedence over everything
+	if hook, ok := s.Hooks[v.Type()]; ok && v.CanInterface() {
+		raw, err := hook(v.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("error calling hook for type %s: %s", v.Type(), err)
+		}
+
+		return s.toValue(raw)
+	}
+
+	// Null and undefined are checked by type since they are singletons.
+	// They implement json.Marshaler, so this must come first.
+	switch v.Type() {
+	case nullTyp:
+		return s.alloc.value(proto.Value_NULL), nil
+
+	case undefinedTyp:
+		return s.alloc.value(proto.Value_UNDEFINED), nil
+	}
+
+	// Big numbers are decimals rather than their usual conversion
+	if s.Decimals {
+		if value, ok := toValue_decimal(v); ok {
+			return value, nil
+		}
+	}
+
+	// Byte slices are base64 strings rather than lists of integers
+	if s.Base64Bytes && v.Type() == bytesTyp {
+		return toValue_bytes(v), nil
+	}
+
+	info := cachedTypeInfo(v.Type())
+
+	// Well-known types have their own conversion
+	if info.wellKnown != nil {
+		return info.wellKnown(v)
+	}
+
+	// Types can implement their own conversion. Pointers to well-known
+	// types are skipped, they are dereferenced and converted below.
+	if info.marshaler || (s.FallbackMarshalers && info.fallbackMarshaler) {
+		if value, ok, err := s.toValue_marshaler(v); ok {
+			return value, err
+		}
 	}
 
 	// Decode depending on the type. We need to redo all of the primitives
@@ -37,60 +137,50 @@ func toValue_reflect(v reflect.Value) (*proto.Value, error) {
 	// wrapped in an interface type.
 	switch v.Kind() {
 	case reflect.Interface:
-		return toValue_reflect(v.Elem())
+		if v.CanInterface() {
+			return s.toValue(v.Interface())
+		}
 
-	case reflect.Ptr:
-		switch v.Interface() {
-		case sdk.Null:
-			return &proto.Value{Type: proto.Value_NULL}, nil
+		return s.toValue_reflect(v.Elem())
 
-		case sdk.Undefined:
-			return &proto.Value{Type: proto.Value_UNDEFINED}, nil
+	case reflect.Ptr:
+		if err := s.visit(v); err != nil {
+			return nil, err
 		}
+		defer s.unvisit(v)
 
-		return toValue_reflect(v.Elem())
+		return s.toValue_reflect(v.Elem())
 
 	case reflect.Bool:
-		return &proto.Value{
-			Type:  proto.Value_BOOL,
-			Value: &proto.Value_ValueBool{ValueBool: v.Bool()},
-		}, nil
+		return s.alloc.boolValue(v.Bool()), nil
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return &proto.Value{
-			Type:  proto.Value_INT,
-			Value: &proto.Value_ValueInt{ValueInt: v.Int()},
-		}, nil
+		return s.alloc.intValue(v.Int()), nil
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return &proto.Value{
-			Type:  proto.Value_INT,
-			Value: &proto.Value_ValueInt{ValueInt: int64(v.Uint())},
-		}, nil
+		if s.Strict && v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("unsigned integer %d overflows int64", v.Uint())
+		}
+
+		return s.alloc.intValue(int64(v.Uint())), nil
 
 	case reflect.Float32, reflect.Float64:
-		return &proto.Value{
-			Type:  proto.Value_FLOAT,
-			Value: &proto.Value_ValueFloat{ValueFloat: v.Float()},
-		}, nil
+		return s.alloc.floatValue(v.Float()), nil
 
 	case reflect.Complex64, reflect.Complex128:
 		return nil, errors.New("cannot convert complex number to Sentinel value")
 
 	case reflect.String:
-		return &proto.Value{
-			Type:  proto.Value_STRING,
-			Value: &proto.Value_ValueString{ValueString: v.String()},
-		}, nil
+		return s.alloc.stringValue(v.String()), nil
 
 	case reflect.Array, reflect.Slice:
-		return toValue_array(v)
+		return s.toValue_array(v)
 
 	case reflect.Map:
-		return toValue_map(v)
+		return s.toValue_map(v)
 
 	case reflect.Struct:
-		return toValue_struct(v)
+		return s.toValue_struct(v)
 
 	case reflect.Chan:
 		return nil, errors.New("cannot convert channel to Sentinel value")
@@ -102,10 +192,22 @@ func toValue_reflect(v reflect.Value) (*proto.Value, error) {
 	return nil, fmt.Errorf("cannot convert type %s to Sentinel value", v.Kind())
 }
 
-func toValue_array(v reflect.Value) (*proto.Value, error) {
+func (s *encodeState) toValue_array(v reflect.Value) (*proto.Value, error) {
+	if err := s.enter(v.Len()); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
+	if err := s.visit(v); err != nil {
+		return nil, err
+	}
+	defer s.unvisit(v)
+
 	vs := make([]*proto.Value, v.Len())
 	for i := range vs {
-		elem, err := toValue_reflect(v.Index(i))
+		s.push(walk.Index(i))
+		elem, err := s.toValue_reflect(v.Index(i))
+		s.pop()
 		if err != nil {
 			return nil, err
 		}
@@ -113,114 +215,318 @@ func toValue_array(v reflect.Value) (*proto.Value, error) {
 		vs[i] = elem
 	}
 
-	return &proto.Value{
-		Type: proto.Value_LIST,
-		Value: &proto.Value_ValueList{
-			ValueList: &proto.Value_List{
-				Elems: vs,
-			},
-		},
-	}, nil
+	return s.alloc.listValue(vs), nil
 }
 
-func toValue_map(v reflect.Value) (*proto.Value, error) {
-	vs := make([]*proto.Value_KV, v.Len())
-	for i, keyV := range v.MapKeys() {
-		key, err := toValue_reflect(keyV)
+// toValue_interfaceSlice is the fast path of toValue_array for
+// []interface{}. raw is x as an interface, for cycle detection.
+func (s *encodeState) toValue_interfaceSlice(raw interface{}, x []interface{}) (*proto.Value, error) {
+	if err := s.enter(len(x)); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
+	v := reflect.ValueOf(raw)
+	if err := s.visit(v); err != nil {
+		return nil, err
+	}
+	defer s.unvisit(v)
+
+	vs := make([]*proto.Value, len(x))
+	for i, raw := range x {
+		s.push(walk.Index(i))
+		elem, err := s.toValue(raw)
+		s.pop()
 		if err != nil {
 			return nil, err
 		}
 
-		value, err := toValue_reflect(v.MapIndex(keyV))
+		vs[i] = elem
+	}
+
+	return s.alloc.listValue(vs), nil
+}
+
+func (s *encodeState) toValue_map(v reflect.Value) (*proto.Value, error) {
+	if err := s.enter(v.Len()); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
+	if err := s.visit(v); err != nil {
+		return nil, err
+	}
+	defer s.unvisit(v)
+
+	vs := kvs(v.Len())
+	iter := v.MapRange()
+	for i := 0; iter.Next(); i++ {
+		keyV := iter.Key()
+		key, err := s.toValue_reflect(keyV)
 		if err != nil {
 			return nil, err
 		}
 
-		vs[i] = &proto.Value_KV{
-			Key:   key,
-			Value: value,
+		s.push(walk.Key(keyV))
+		value, err := s.toValue_reflect(iter.Value())
+		s.pop()
+		if err != nil {
+			return nil, err
 		}
+
+		vs[i].Key = key
+		vs[i].Value = value
+	}
+
+	if !s.UnsortedMaps {
+		sort.Sort(kvByKey(vs))
 	}
 
-	return &proto.Value{
-		Type: proto.Value_MAP,
-		Value: &proto.Value_ValueMap{
-			ValueMap: &proto.Value_Map{
-				Elems: vs,
-			},
-		},
-	}, nil
+	return s.alloc.mapValue(vs), nil
 }
 
-func toValue_struct(v reflect.Value) (*proto.Value, error) {
-	// Get the type since we need this to determine what is exported,
-	// field tags, etc.
-	t := v.Type()
+// toValue_stringMap is the fast path of toValue_map for
+// map[string]interface{}. raw is x as an interface, for cycle detection.
+func (s *encodeState) toValue_stringMap(raw interface{}, x map[string]interface{}) (*proto.Value, error) {
+	if err := s.enter(len(x)); err != nil {
+		return nil, err
+	}
+	defer s.leave()
 
-	vs := make([]*proto.Value_KV, 0, v.NumField())
-	for i := 0; i < v.NumField(); i++ {
-		field := t.Field(i)
+	v := reflect.ValueOf(raw)
+	if err := s.visit(v); err != nil {
+		return nil, err
+	}
+	defer s.unvisit(v)
+
+	// Sorting the keys as strings gives the same order as CompareValues
+	keys := make([]string, 0, len(x))
+	for k := range x {
+		keys = append(keys, k)
+	}
+	if !s.UnsortedMaps {
+		sort.Strings(keys)
+	}
+
+	vs := kvs(len(keys))
+	for i, k := range keys {
+		s.push(walk.Name(k))
+		value, err := s.toValue(x[k])
+		s.pop()
+		if err != nil {
+			return nil, err
+		}
+
+		vs[i].Key = s.alloc.stringValue(k)
+		vs[i].Value = value
+	}
+
+	return s.alloc.mapValue(vs), nil
+}
+
+func (s *encodeState) toValue_struct(v reflect.Value) (*proto.Value, error) {
+	// The size of a struct is only known once its fields are converted,
+	// so it is checked separately below.
+	if err := s.enter(0); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
+	info := cachedTypeInfo(v.Type())
+	fields, err := s.toValue_structFields(v, 0, make([]structField, 0, len(info.fields)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve duplicate keys from inlined structs. As with encoding/json,
+	// the shallowest field wins, and for fields at the same depth the
+	// first declared wins. Without inlined structs, keys are unique.
+	if info.inline {
+		fields = dedupeFields(fields)
+	}
+
+	s.size += len(fields)
+	if err := checkLimits(s.depth, s.size, s.MaxDepth, s.MaxSize); err != nil {
+		return nil, fmt.Errorf("%s at %s", err, walk.FormatPath(s.path))
+	}
+
+	vs := kvs(len(fields))
+	for i, f := range fields {
+		vs[i].Key = s.alloc.stringValue(f.key)
+		vs[i].Value = f.value
+	}
+
+	return s.alloc.mapValue(vs), nil
+}
+
+// dedupeFields removes fields with duplicate keys, keeping the shallowest
+// field, or the first of fields at the same depth.
+func dedupeFields(fields []structField) []structField {
+	index := make(map[string]int, len(fields))
+	result := make([]structField, 0, len(fields))
+	for _, f := range fields {
+		if idx, ok := index[f.key]; ok {
+			if f.depth < result[idx].depth {
+				result[idx] = f
+			}
 
-		// If PkgPath is non-empty, this is unexported and can be ignored
-		if field.PkgPath != "" {
 			continue
 		}
 
-		// Determine the map key
-		key := toValue_struct_fieldName([]rune(field.Name))
-		if v, ok := field.Tag.Lookup("sentinel"); ok {
-			// A blank value means to not export this value
-			if v == "" {
-				continue
+		index[f.key] = len(result)
+		result = append(result, f)
+	}
+
+	return result
+}
+
+// structField is a single converted field of a struct.
+type structField struct {
+	key   string
+	value *proto.Value
+	depth int // nesting depth for inlined fields
+}
+
+// structTag is the parsed "sentinel" struct tag of a field.
+type structTag struct {
+	name      string
+	skip      bool
+	omitEmpty bool
+	inline    bool
+	asString  bool
+	memo      string
+}
+
+// parseStructTag parses the "sentinel" struct tag of a field. The tag is
+// a name followed by comma-separated options, as with encoding/json.
+//
+// For backwards compatibility, an empty tag excludes the field, as does a
+// name of "-".
+func parseStructTag(field reflect.StructField) (structTag, error) {
+	raw, ok := field.Tag.Lookup("sentinel")
+	if !ok {
+		return structTag{}, nil
+	}
+
+	if raw == "" || raw == "-" {
+		return structTag{skip: true}, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	result := structTag{name: parts[0]}
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "omitempty":
+			result.omitEmpty = true
+
+		case opt == "inline":
+			result.inline = true
+
+		case opt == "string":
+			result.asString = true
+
+		// The memoization policy is only used by framework namespaces
+		case strings.HasPrefix(opt, "memo="):
+			result.memo = strings.TrimPrefix(opt, "memo=")
+
+		default:
+			return result, fmt.Errorf(
+				"field %s: unknown sentinel tag option %q", field.Name, opt)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *encodeState) toValue_structFields(v reflect.Value, depth int, result []structField) ([]structField, error) {
+	// The exported fields and their tags are cached by type
+	for _, field := range cachedTypeInfo(v.Type()).fields {
+		if field.err != nil {
+			return nil, field.err
+		}
+
+		tag := field.tag
+		fv := v.Field(field.index)
+		if tag.omitEmpty && tagopt.IsEmpty(fv) {
+			continue
+		}
+
+		if tag.inline {
+			var err error
+			result, err = s.toValue_inline(fv, field.name, depth+1, result)
+			if err != nil {
+				return nil, err
 			}
 
-			key = v
+			continue
+		}
+
+		// Determine the map key
+		key := tag.name
+		if key == "" {
+			key = s.fieldName(field.name)
 		}
 
 		// Convert the value
-		value, err := toValue_reflect(v.Field(i))
+		var value *proto.Value
+		var err error
+		s.push(walk.Name(key))
+		if tag.asString {
+			value, err = s.toValue_string(fv)
+		} else {
+			value, err = s.toValue_reflect(fv)
+		}
+		s.pop()
 		if err != nil {
 			return nil, err
 		}
 
-		vs = append(vs, &proto.Value_KV{
-			Value: value,
-			Key: &proto.Value{
-				Type:  proto.Value_STRING,
-				Value: &proto.Value_ValueString{ValueString: key},
-			},
-		})
+		result = append(result, structField{key: key, value: value, depth: depth})
 	}
 
-	return &proto.Value{
-		Type: proto.Value_MAP,
-		Value: &proto.Value_ValueMap{
-			ValueMap: &proto.Value_Map{
-				Elems: vs,
-			},
-		},
-	}, nil
+	return result, nil
 }
 
-func toValue_struct_fieldName(s []rune) string {
-	var result []string
-	var last int
-
-	// Always convert the zero-index rune to a lowercase letter. Since we always
-	// operate on exported struct fields, this is fine and is actually less
-	// costly than doing an IsUpper first.
-	s[0] = unicode.ToLower(s[0])
+// toValue_inline appends the fields of the embedded struct fv, named
+// name, to result. Embedded pointers are visited like any other pointer,
+// since they may refer back to a struct being inlined, and inlined structs
+// count toward the maximum depth.
+func (s *encodeState) toValue_inline(fv reflect.Value, name string, depth int, result []structField) ([]structField, error) {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			// A nil embedded pointer has no fields to inline
+			return result, nil
+		}
 
-	for idx := 1; idx < len(s); idx++ {
-		if unicode.IsUpper(s[idx]) {
-			result = append(result, string(s[last:idx]))
-			last = idx
-			s[idx] = unicode.ToLower(s[idx])
+		if err := s.visit(fv); err != nil {
+			return nil, err
 		}
+		defer s.unvisit(fv)
+
+		return s.toValue_inline(fv.Elem(), name, depth, result)
+	}
+
+	if fv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf(
+			"field %s: cannot inline %s, only structs can be inlined",
+			name, fv.Kind())
+	}
+
+	if err := s.enter(0); err != nil {
+		return nil, err
 	}
+	defer s.leave()
+
+	return s.toValue_structFields(fv, depth, result)
+}
 
-	// Append anything remaining
-	result = append(result, string(s[last:]))
+// toValue_string converts a value to a string for the "string" tag
+// option. Only booleans and numbers are affected, as with encoding/json.
+func (s *encodeState) toValue_string(v reflect.Value) (*proto.Value, error) {
+	str, ok := tagopt.FormatString(v)
+	if !ok {
+		return s.toValue_reflect(v)
+	}
 
-	return strings.Join(result, "_")
+	return s.alloc.stringValue(str), nil
 }
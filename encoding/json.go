@@ -0,0 +1,418 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// MarshalJSON converts the Go value to JSON in the plain form of
+// ValueToJSON. Unlike encoding/json, this follows the conversion rules of
+// GoToValue, so map entries and struct fields that are sdk.Undefined are
+// omitted.
+func MarshalJSON(raw interface{}) ([]byte, error) {
+	v, err := GoToValue(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return ValueToJSON(v)
+}
+
+// ValueToJSON converts the value to plain JSON, as a person would write
+// it. This form is lossy:
+//
+//   - undefined map values are omitted, and undefined elsewhere is null
+//   - integers, floats and decimals are all JSON numbers
+//   - map keys are converted to strings, so keys must be strings,
+//     numbers, booleans or null
+//
+// Use ValueToTaggedJSON for a form that preserves the value exactly.
+func ValueToJSON(v *proto.Value) ([]byte, error) {
+	raw, _, err := plainJSON(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(raw)
+}
+
+// JSONToValue converts plain JSON to a value. Numbers without a fraction
+// or exponent that fit in an int64 become integers, and all other numbers
+// become floats.
+func JSONToValue(data []byte) (*proto.Value, error) {
+	raw, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return GoToValue(raw)
+}
+
+// plainJSON converts the value to a Go value for encoding/json. The
+// boolean result is false if the value is undefined.
+func plainJSON(v *proto.Value) (interface{}, bool, error) {
+	switch v.Type {
+	case proto.Value_UNDEFINED:
+		return nil, false, nil
+
+	case proto.Value_NULL:
+		return nil, true, nil
+
+	case proto.Value_BOOL:
+		return v.GetValueBool(), true, nil
+
+	case proto.Value_INT:
+		return v.GetValueInt(), true, nil
+
+	case proto.Value_FLOAT:
+		return v.GetValueFloat(), true, nil
+
+	case proto.Value_DECIMAL:
+		if _, err := parseDecimal(v.GetValueDecimal()); err != nil {
+			return nil, false, err
+		}
+
+		return json.Number(v.GetValueDecimal()), true, nil
+
+	case proto.Value_STRING:
+		return v.GetValueString(), true, nil
+
+	case proto.Value_LIST:
+		elems := v.GetValueList().GetElems()
+		result := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			raw, _, err := plainJSON(elem)
+			if err != nil {
+				return nil, false, fmt.Errorf("element %d: %s", i, err)
+			}
+
+			result[i] = raw
+		}
+
+		return result, true, nil
+
+	case proto.Value_MAP:
+		elems := v.GetValueMap().GetElems()
+		result := make(map[string]interface{}, len(elems))
+		for _, elem := range elems {
+			key, err := plainJSONKey(elem.Key)
+			if err != nil {
+				return nil, false, err
+			}
+
+			raw, ok, err := plainJSON(elem.Value)
+			if err != nil {
+				return nil, false, fmt.Errorf("element for key %q: %s", key, err)
+			}
+
+			if ok {
+				result[key] = raw
+			}
+		}
+
+		return result, true, nil
+
+	default:
+		return nil, false, convertErr(v, "JSON")
+	}
+}
+
+// plainJSONKey converts a map key to a JSON object key.
+func plainJSONKey(v *proto.Value) (string, error) {
+	switch v.Type {
+	case proto.Value_NULL:
+		return "null", nil
+
+	case proto.Value_BOOL:
+		return strconv.FormatBool(v.GetValueBool()), nil
+
+	case proto.Value_INT:
+		return strconv.FormatInt(v.GetValueInt(), 10), nil
+
+	case proto.Value_FLOAT:
+		return strconv.FormatFloat(v.GetValueFloat(), 'g', -1, 64), nil
+
+	case proto.Value_DECIMAL:
+		return v.GetValueDecimal(), nil
+
+	case proto.Value_STRING:
+		return v.GetValueString(), nil
+
+	default:
+		return "", convertErr(v, "JSON object key")
+	}
+}
+
+// ValueToTaggedJSON converts the value to JSON that preserves the type of
+// every value, so that TaggedJSONToValue returns an identical value. This
+// is suitable for fixtures and for dumping results for later comparison.
+//
+// Every value is an object with a single key naming its type:
+//
+//	{"undefined": true}
+//	{"null": true}
+//	{"bool": true}
+//	{"int": "42"}
+//	{"float": 1.5}
+//	{"decimal": "18446744073709551616"}
+//	{"string": "foo"}
+//	{"list": [{"int": "1"}, {"int": "2"}]}
+//	{"map": [{"key": {"string": "a"}, "value": {"int": "1"}}]}
+//
+// Integers are strings, since JSON numbers don't have the precision of an
+// int64 for most JSON parsers. Floats that are not finite are the strings
+// "NaN", "Infinity" and "-Infinity". Map elements are kept in order.
+func ValueToTaggedJSON(v *proto.Value) ([]byte, error) {
+	raw, err := taggedJSON(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(raw)
+}
+
+// TaggedJSONToValue converts JSON in the form of ValueToTaggedJSON to a
+// value.
+func TaggedJSONToValue(data []byte) (*proto.Value, error) {
+	return fromTaggedJSON(json.RawMessage(data))
+}
+
+// taggedKV is a map element in tagged JSON.
+type taggedKV struct {
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+func taggedJSON(v *proto.Value) (interface{}, error) {
+	switch v.Type {
+	case proto.Value_UNDEFINED:
+		return map[string]bool{"undefined": true}, nil
+
+	case proto.Value_NULL:
+		return map[string]bool{"null": true}, nil
+
+	case proto.Value_BOOL:
+		return map[string]bool{"bool": v.GetValueBool()}, nil
+
+	case proto.Value_INT:
+		return map[string]string{"int": strconv.FormatInt(v.GetValueInt(), 10)}, nil
+
+	case proto.Value_FLOAT:
+		f := v.GetValueFloat()
+		switch {
+		case math.IsNaN(f):
+			return map[string]string{"float": "NaN"}, nil
+
+		case math.IsInf(f, 1):
+			return map[string]string{"float": "Infinity"}, nil
+
+		case math.IsInf(f, -1):
+			return map[string]string{"float": "-Infinity"}, nil
+
+		default:
+			return map[string]float64{"float": f}, nil
+		}
+
+	case proto.Value_DECIMAL:
+		return map[string]string{"decimal": v.GetValueDecimal()}, nil
+
+	case proto.Value_STRING:
+		return map[string]string{"string": v.GetValueString()}, nil
+
+	case proto.Value_LIST:
+		elems := v.GetValueList().GetElems()
+		result := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			raw, err := taggedJSON(elem)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %s", i, err)
+			}
+
+			result[i] = raw
+		}
+
+		return map[string]interface{}{"list": result}, nil
+
+	case proto.Value_MAP:
+		elems := v.GetValueMap().GetElems()
+		result := make([]map[string]interface{}, len(elems))
+		for i, elem := range elems {
+			key, err := taggedJSON(elem.Key)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %s", i, err)
+			}
+
+			value, err := taggedJSON(elem.Value)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %s", i, err)
+			}
+
+			result[i] = map[string]interface{}{"key": key, "value": value}
+		}
+
+		return map[string]interface{}{"map": result}, nil
+
+	default:
+		return nil, convertErr(v, "JSON")
+	}
+}
+
+func fromTaggedJSON(data json.RawMessage) (*proto.Value, error) {
+	var tagged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return nil, err
+	}
+
+	if len(tagged) != 1 {
+		return nil, fmt.Errorf("tagged value must have exactly one key, got %d", len(tagged))
+	}
+
+	var typ string
+	var raw json.RawMessage
+	for typ, raw = range tagged {
+		// There is exactly one key, checked above
+	}
+
+	switch typ {
+	case "undefined":
+		return &proto.Value{Type: proto.Value_UNDEFINED}, nil
+
+	case "null":
+		return &proto.Value{Type: proto.Value_NULL}, nil
+
+	case "bool":
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, fmt.Errorf("bool: %s", err)
+		}
+
+		return &proto.Value{
+			Type:  proto.Value_BOOL,
+			Value: &proto.Value_ValueBool{ValueBool: b},
+		}, nil
+
+	case "int":
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, fmt.Errorf("int: %s", err)
+		}
+
+		i, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("int: %s", err)
+		}
+
+		return intValue(i), nil
+
+	case "float":
+		var f float64
+		if err := json.Unmarshal(raw, &f); err != nil {
+			// Non-finite floats are strings
+			var str string
+			if json.Unmarshal(raw, &str) != nil {
+				return nil, fmt.Errorf("float: %s", err)
+			}
+
+			switch str {
+			case "NaN":
+				f = math.NaN()
+
+			case "Infinity":
+				f = math.Inf(1)
+
+			case "-Infinity":
+				f = math.Inf(-1)
+
+			default:
+				return nil, fmt.Errorf("float: invalid value %q", str)
+			}
+		}
+
+		return floatValue(f), nil
+
+	case "decimal":
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, fmt.Errorf("decimal: %s", err)
+		}
+
+		if _, err := parseDecimal(str); err != nil {
+			return nil, fmt.Errorf("decimal: %s", err)
+		}
+
+		return decimalValue(str), nil
+
+	case "string":
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, fmt.Errorf("string: %s", err)
+		}
+
+		return stringValue(str), nil
+
+	case "list":
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return nil, fmt.Errorf("list: %s", err)
+		}
+
+		vs := make([]*proto.Value, len(elems))
+		for i, elem := range elems {
+			v, err := fromTaggedJSON(elem)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %s", i, err)
+			}
+
+			vs[i] = v
+		}
+
+		return &proto.Value{
+			Type: proto.Value_LIST,
+			Value: &proto.Value_ValueList{
+				ValueList: &proto.Value_List{Elems: vs},
+			},
+		}, nil
+
+	case "map":
+		var elems []taggedKV
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return nil, fmt.Errorf("map: %s", err)
+		}
+
+		vs := make([]*proto.Value_KV, len(elems))
+		for i, elem := range elems {
+			if elem.Key == nil || elem.Value == nil {
+				return nil, fmt.Errorf("map element %d must have a key and value", i)
+			}
+
+			key, err := fromTaggedJSON(elem.Key)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %s", i, err)
+			}
+
+			value, err := fromTaggedJSON(elem.Value)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %s", i, err)
+			}
+
+			vs[i] = &proto.Value_KV{Key: key, Value: value}
+		}
+
+		return &proto.Value{
+			Type: proto.Value_MAP,
+			Value: &proto.Value_ValueMap{
+				ValueMap: &proto.Value_Map{Elems: vs},
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown value type %q", typ)
+	}
+}
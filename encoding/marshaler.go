@@ -0,0 +1,264 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/hashicorp/sentinel-sdk/internal/walk"
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// SentinelMarshaler is the interface implemented by types that control
+// their own conversion to a Sentinel value.
+type SentinelMarshaler interface {
+	// MarshalSentinel returns the value to convert in place of the
+	// receiver. The returned value is converted with the same rules as
+	// GoToValue.
+	MarshalSentinel() (interface{}, error)
+}
+
+// SentinelUnmarshaler is the interface implemented by types that control
+// their own conversion from a Sentinel value. UnmarshalSentinel must copy
+// any data it wishes to retain after returning.
+type SentinelUnmarshaler interface {
+	UnmarshalSentinel(*proto.Value) error
+}
+
+var (
+	sentinelMarshalerTyp   = reflect.TypeOf((*SentinelMarshaler)(nil)).Elem()
+	sentinelUnmarshalerTyp = reflect.TypeOf((*SentinelUnmarshaler)(nil)).Elem()
+	jsonMarshalerTyp       = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerTyp     = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerTyp       = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerTyp     = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// DefaultMaxMarshalerDepth is the maximum nesting of marshaler calls if
+// Encoder.MaxDepth isn't set, so that a marshaler returning a value that
+// marshals again, such as a value of its own type, returns an error rather
+// than exhausting the stack.
+const DefaultMaxMarshalerDepth = 1000
+
+// toValue_marshaler converts v using the marshaler interfaces it
+// implements, if any. The boolean result is false if v implements none of
+// them. json.Marshaler and encoding.TextMarshaler are only used if
+// FallbackMarshalers is set.
+//
+// As with encoding/json, methods with pointer receivers are only used if
+// the value is addressable. SentinelMarshaler takes precedence over
+// json.Marshaler, which takes precedence over encoding.TextMarshaler.
+func (s *encodeState) toValue_marshaler(v reflect.Value) (*proto.Value, bool, error) {
+	if m, ok := implements(v, sentinelMarshalerTyp); ok {
+		raw, err := m.Interface().(SentinelMarshaler).MarshalSentinel()
+		if err != nil {
+			return nil, true, fmt.Errorf("error calling MarshalSentinel for type %s: %s", v.Type(), err)
+		}
+
+		value, err := s.toValue_marshaled(m, raw)
+		return value, true, err
+	}
+
+	if !s.FallbackMarshalers {
+		return nil, false, nil
+	}
+
+	if m, ok := implements(v, jsonMarshalerTyp); ok {
+		data, err := m.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return nil, true, fmt.Errorf("error calling MarshalJSON for type %s: %s", v.Type(), err)
+		}
+
+		raw, err := decodeJSON(data)
+		if err != nil {
+			return nil, true, fmt.Errorf("error decoding JSON for type %s: %s", v.Type(), err)
+		}
+
+		value, err := s.toValue_marshaled(m, raw)
+		return value, true, err
+	}
+
+	if m, ok := implements(v, textMarshalerTyp); ok {
+		text, err := m.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, true, fmt.Errorf("error calling MarshalText for type %s: %s", v.Type(), err)
+		}
+
+		return stringValue(string(text)), true, nil
+	}
+
+	return nil, false, nil
+}
+
+// toValue_marshaled converts raw, the value returned by the marshaler of
+// m. Marshaler calls count toward the maximum depth, and pointers they are
+// called on are visited to detect cycles, since the returned value may
+// marshal again.
+func (s *encodeState) toValue_marshaled(m reflect.Value, raw interface{}) (*proto.Value, error) {
+	if err := s.visit(m); err != nil {
+		return nil, err
+	}
+	defer s.unvisit(m)
+
+	if err := s.enter(0); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
+	s.marshalDepth++
+	defer func() { s.marshalDepth-- }()
+	if s.MaxDepth == 0 && s.marshalDepth > DefaultMaxMarshalerDepth {
+		return nil, fmt.Errorf("maximum depth of %d marshaler calls exceeded at %s",
+			DefaultMaxMarshalerDepth, walk.FormatPath(s.path))
+	}
+
+	return s.toValue(raw)
+}
+
+// toGo_unmarshaler converts raw to a value of type t using the
+// unmarshaler interfaces a pointer to t implements, if any. The boolean
+// result is false if it implements none of them. json.Unmarshaler and
+// encoding.TextUnmarshaler are only used if FallbackUnmarshalers is set.
+//
+// SentinelUnmarshaler takes precedence over json.Unmarshaler, which takes
+// precedence over encoding.TextUnmarshaler.
+func (s *decodeState) toGo_unmarshaler(raw *proto.Value, t reflect.Type) (interface{}, bool, error) {
+	// Pointers are dereferenced before we get here, see convertValuePtr.
+	// Interfaces have no concrete type to construct.
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return nil, false, nil
+	}
+
+	ptr := reflect.New(t)
+	if u, ok := ptr.Interface().(SentinelUnmarshaler); ok {
+		if err := u.UnmarshalSentinel(raw); err != nil {
+			return nil, true, err
+		}
+
+		return ptr.Elem().Interface(), true, nil
+	}
+
+	if !s.FallbackUnmarshalers {
+		return nil, false, nil
+	}
+
+	switch u := ptr.Interface().(type) {
+	case json.Unmarshaler:
+		if raw.Type == proto.Value_UNDEFINED {
+			return nil, true, convertErr(raw, t.String())
+		}
+
+		value, err := s.valueToGo(raw, nil)
+		if err != nil {
+			return nil, true, err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, true, err
+		}
+
+		if err := u.UnmarshalJSON(data); err != nil {
+			return nil, true, err
+		}
+
+	case encoding.TextUnmarshaler:
+		text, err := s.convertValueString(raw)
+		if err != nil {
+			return nil, true, err
+		}
+
+		if err := u.UnmarshalText([]byte(text.(string))); err != nil {
+			return nil, true, err
+		}
+
+	default:
+		return nil, false, nil
+	}
+
+	return ptr.Elem().Interface(), true, nil
+}
+
+// implements returns the value to call the methods of iface on, if v
+// implements it either directly or through its address.
+func implements(v reflect.Value, iface reflect.Type) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Interface:
+		// The value within the interface is checked when it is converted.
+		return v, false
+
+	case reflect.Ptr:
+		// Nil pointers are converted to null rather than calling methods
+		// on them, as with encoding/json.
+		if v.IsNil() {
+			return v, false
+		}
+	}
+
+	if v.Type().Implements(iface) {
+		return v, true
+	}
+
+	if v.Kind() != reflect.Ptr && v.CanAddr() && reflect.PtrTo(v.Type()).Implements(iface) {
+		return v.Addr(), true
+	}
+
+	return v, false
+}
+
+// decodeJSON decodes JSON data into plain Go values. Numbers are decoded
+// as int64 if they are integers, and float64 otherwise.
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var result interface{}
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid data after top-level JSON value")
+	}
+
+	return fromJSONNumbers(result), nil
+}
+
+func fromJSONNumbers(raw interface{}) interface{} {
+	switch x := raw.(type) {
+	case json.Number:
+		if v, err := x.Int64(); err == nil {
+			return v
+		}
+
+		if v, err := x.Float64(); err == nil {
+			return v
+		}
+
+		return x.String()
+
+	case []interface{}:
+		for i, elem := range x {
+			x[i] = fromJSONNumbers(elem)
+		}
+
+		return x
+
+	case map[string]interface{}:
+		for k, elem := range x {
+			x[k] = fromJSONNumbers(elem)
+		}
+
+		return x
+
+	default:
+		return raw
+	}
+}
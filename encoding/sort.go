@@ -0,0 +1,151 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"bytes"
+	"math"
+	"strings"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// CompareValues compares two values, returning -1, 0 or +1 if a is less
+// than, equal to, or greater than b. This is the order GoToValue uses for
+// map keys.
+//
+// Values of different types are ordered by type: undefined, null, bool,
+// numbers, string, list, then map. Integers, floats and decimals are
+// compared together by numeric value, ordering integers before floats and
+// floats before decimals of equal value; NaN is ordered before all other
+// numbers. Strings are compared bytewise, and false is ordered before
+// true. Lists and maps are ordered by their deterministic protobuf
+// encoding, which has no meaning beyond being stable.
+func CompareValues(a, b *proto.Value) int {
+	if ra, rb := typeRank(a), typeRank(b); ra != rb {
+		return compareInts(ra, rb)
+	}
+
+	switch a.Type {
+	case proto.Value_BOOL:
+		ab, bb := a.GetValueBool(), b.GetValueBool()
+		switch {
+		case ab == bb:
+			return 0
+		case !ab:
+			return -1
+		default:
+			return 1
+		}
+
+	case proto.Value_INT, proto.Value_FLOAT, proto.Value_DECIMAL:
+		if c := compareNumbers(a, b); c != 0 {
+			return c
+		}
+
+		// Equal numeric value, order integers first, then floats, then
+		// decimals
+		return compareInts(int64(a.Type), int64(b.Type))
+
+	case proto.Value_STRING:
+		return strings.Compare(a.GetValueString(), b.GetValueString())
+
+	case proto.Value_LIST, proto.Value_MAP:
+		opts := protobuf.MarshalOptions{Deterministic: true}
+
+		// Values are always valid messages, so an error here is not
+		// possible.
+		ab, _ := opts.Marshal(a)
+		bb, _ := opts.Marshal(b)
+		return bytes.Compare(ab, bb)
+
+	default:
+		// Undefined, null, and invalid values are all equal to their own
+		// type.
+		return 0
+	}
+}
+
+// typeRank returns the position of the value's type in the ordering of
+// CompareValues.
+func typeRank(v *proto.Value) int64 {
+	switch v.Type {
+	case proto.Value_UNDEFINED:
+		return 1
+	case proto.Value_NULL:
+		return 2
+	case proto.Value_BOOL:
+		return 3
+	case proto.Value_INT, proto.Value_FLOAT, proto.Value_DECIMAL:
+		return 4
+	case proto.Value_STRING:
+		return 5
+	case proto.Value_LIST:
+		return 6
+	case proto.Value_MAP:
+		return 7
+	default:
+		return 0
+	}
+}
+
+// compareNumbers compares the numeric values of two INT, FLOAT or DECIMAL
+// values.
+func compareNumbers(a, b *proto.Value) int {
+	switch {
+	case a.Type == proto.Value_INT && b.Type == proto.Value_INT:
+		return compareInts(a.GetValueInt(), b.GetValueInt())
+
+	case a.Type == proto.Value_DECIMAL || b.Type == proto.Value_DECIMAL:
+		return compareDecimals(a, b)
+
+	default:
+		return compareFloats(numberValue(a), numberValue(b))
+	}
+}
+
+func numberValue(v *proto.Value) float64 {
+	if v.Type == proto.Value_INT {
+		return float64(v.GetValueInt())
+	}
+
+	return v.GetValueFloat()
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return -1
+	case math.IsNaN(b):
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// kvByKey sorts map elements by their key with CompareValues.
+type kvByKey []*proto.Value_KV
+
+func (s kvByKey) Len() int           { return len(s) }
+func (s kvByKey) Less(i, j int) bool { return CompareValues(s[i].Key, s[j].Key) < 0 }
+func (s kvByKey) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
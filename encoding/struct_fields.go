@@ -0,0 +1,138 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StructField is a field of a struct type as GoToValue converts it. This
+// allows other packages, such as framework, to expose structs with the
+// same keys as GoToValue.
+type StructField struct {
+	// Key is the map key of the field.
+	Key string
+
+	// Index is the index sequence of the field for
+	// reflect.Value.FieldByIndex. Fields of inlined structs have more than
+	// one index, and may be behind nil embedded pointers.
+	Index []int
+
+	// OmitEmpty and AsString are set for the "omitempty" and "string" tag
+	// options.
+	OmitEmpty bool
+	AsString  bool
+
+	// Memo is the value of the "memo" tag option, such as "lazy". It is
+	// not used by GoToValue.
+	Memo string
+}
+
+// StructFields returns the fields of the struct type t that GoToValue
+// converts, in the order they are converted. The fields of inlined structs
+// are flattened into the result, with duplicate keys resolved as they are
+// by GoToValue. Keys of fields without a name in their tag are named with
+// naming, or LegacySnakeCase if it is nil, as with Encoder.FieldNaming.
+func StructFields(t reflect.Type, naming FieldNamer) ([]StructField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct, got %s", t)
+	}
+
+	fields, err := structFields(t, naming, nil, map[reflect.Type]bool{t: true}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve duplicate keys, keeping the shallowest field, or the first
+	// of fields at the same depth.
+	index := make(map[string]int, len(fields))
+	result := make([]StructField, 0, len(fields))
+	for _, f := range fields {
+		if idx, ok := index[f.Key]; ok {
+			if len(f.Index) < len(result[idx].Index) {
+				result[idx] = f
+			}
+
+			continue
+		}
+
+		index[f.Key] = len(result)
+		result = append(result, f)
+	}
+
+	return result, nil
+}
+
+// structFields appends the fields of t to result. The types being
+// flattened are tracked in inlining, since a struct that inlines itself
+// through a pointer has no fixed set of fields; its fields are only
+// flattened once.
+func structFields(t reflect.Type, naming FieldNamer, prefix []int, inlining map[reflect.Type]bool, result []StructField) ([]StructField, error) {
+	for _, field := range cachedTypeInfo(t).fields {
+		if field.err != nil {
+			return nil, field.err
+		}
+
+		index := make([]int, len(prefix)+1)
+		copy(index, prefix)
+		index[len(prefix)] = field.index
+
+		tag := field.tag
+		if tag.inline {
+			ft := t.Field(field.index).Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() != reflect.Struct {
+				return nil, fmt.Errorf(
+					"field %s: cannot inline %s, only structs can be inlined",
+					field.name, ft.Kind())
+			}
+
+			if inlining[ft] {
+				continue
+			}
+
+			inlining[ft] = true
+			var err error
+			result, err = structFields(ft, naming, index, inlining, result)
+			delete(inlining, ft)
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		key := tag.name
+		if key == "" {
+			key = naming.name(field.name)
+		}
+
+		result = append(result, StructField{
+			Key:       key,
+			Index:     index,
+			OmitEmpty: tag.omitEmpty,
+			AsString:  tag.asString,
+			Memo:      tag.memo,
+		})
+	}
+
+	return result, nil
+}
+
+// HasCustomConversion reports whether GoToValue converts values of type t
+// with the conversion for a well-known type or SentinelMarshaler, rather
+// than by the kind of t. A pointer has a custom conversion if its
+// element type does.
+func HasCustomConversion(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	info := cachedTypeInfo(t)
+	return info.wellKnown != nil || info.marshaler
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"reflect"
+	"sync"
+
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// typeInfo is what GoToValue needs to know about a type, cached to avoid
+// repeating the checks for every value of the type.
+type typeInfo struct {
+	// wellKnown is the conversion for a well-known type, if any.
+	wellKnown func(reflect.Value) (*proto.Value, error)
+
+	// marshaler is true if the type or a pointer to it may implement
+	// SentinelMarshaler, and fallbackMarshaler if it may implement
+	// json.Marshaler or encoding.TextMarshaler, see toValue_marshaler.
+	marshaler         bool
+	fallbackMarshaler bool
+
+	// fields are the fields of a struct that may be converted, and
+	// inline is true if any of them are inlined.
+	fields []fieldInfo
+	inline bool
+}
+
+// fieldInfo is a struct field that may be converted.
+type fieldInfo struct {
+	index int
+	name  string
+	tag   structTag
+	err   error // error parsing the tag, returned when converting
+}
+
+var typeInfoCache sync.Map // map[reflect.Type]*typeInfo
+
+// cachedTypeInfo returns the typeInfo for t.
+func cachedTypeInfo(t reflect.Type) *typeInfo {
+	if info, ok := typeInfoCache.Load(t); ok {
+		return info.(*typeInfo)
+	}
+
+	info := &typeInfo{wellKnown: wellKnownToValue[t]}
+
+	// Pointers to well-known types are dereferenced and converted rather
+	// than using the marshaler interfaces.
+	if info.wellKnown == nil && (t.Kind() != reflect.Ptr || wellKnownToValue[t.Elem()] == nil) {
+		info.marshaler = implementsAny(t, sentinelMarshalerTyp)
+		info.fallbackMarshaler = implementsAny(t, jsonMarshalerTyp, textMarshalerTyp)
+	}
+
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag, err := parseStructTag(field)
+
+			// If PkgPath is non-empty, this is unexported and can be
+			// ignored, along with any error in its tag. Unexported
+			// embedded structs can still be inlined, since their exported
+			// fields are promoted.
+			if field.PkgPath != "" && !(field.Anonymous && tag.inline) {
+				continue
+			}
+
+			if tag.skip {
+				continue
+			}
+
+			info.inline = info.inline || tag.inline
+			info.fields = append(info.fields, fieldInfo{
+				index: i,
+				name:  field.Name,
+				tag:   tag,
+				err:   err,
+			})
+		}
+	}
+
+	actual, _ := typeInfoCache.LoadOrStore(t, info)
+	return actual.(*typeInfo)
+}
+
+// implementsAny reports whether t, or a pointer to t, implements any of
+// the interfaces.
+func implementsAny(t reflect.Type, interfaces ...reflect.Type) bool {
+	for _, iface := range interfaces {
+		if t.Implements(iface) || (t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(iface)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -5,6 +5,7 @@ package encoding
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 
@@ -20,18 +21,58 @@ var (
 	stringTyp    = reflect.TypeOf("")
 )
 
+// maxExactFloat is the largest magnitude of integer that can be
+// represented exactly as a float64.
+const maxExactFloat = 1 << 53
+
 // ValueToGo converts a protobuf Value structure to a native Go value.
+//
+// If t is a well-known type such as time.Time, or a pointer to one, the
+// value is converted from its Sentinel representation. Other types can
+// control their conversion by implementing SentinelUnmarshaler through a
+// pointer receiver. See the package documentation for details.
+//
+// Maps can be converted to structs. Keys are matched to fields by the
+// name GoToValue would give them, including struct tag options, and keys
+// that match no field are ignored.
+//
+// To convert with options other than the defaults, use a Decoder.
 func ValueToGo(v *proto.Value, t reflect.Type) (interface{}, error) {
-	return valueToGo(v, t)
+	var d Decoder
+	return d.Decode(v, t)
 }
 
-func valueToGo(v *proto.Value, t reflect.Type) (interface{}, error) {
+func (s *decodeState) valueToGo(v *proto.Value, t reflect.Type) (interface{}, error) {
 	// t == nil if you call reflect.TypeOf(interface{}{}) or
 	// if the user explicitly send in nil which we make to mean
 	// the same thing.
 	kind := reflect.Interface
 	if t != nil {
 		kind = t.Kind()
+
+		// Hooks take precedence over everything
+		if hook, ok := s.Hooks[t]; ok {
+			value, err := hook(v)
+			if err != nil {
+				return nil, fmt.Errorf("error calling hook for type %s: %s", t, err)
+			}
+
+			if value != nil && !reflect.TypeOf(value).AssignableTo(t) {
+				return nil, fmt.Errorf("hook for type %s returned %T", t, value)
+			}
+
+			return value, nil
+		}
+
+		// Well-known types have their own conversion
+		if f, ok := wellKnownToGo[t]; ok {
+			return f(v)
+		}
+
+		// Types can implement their own conversion
+		if value, ok, err := s.toGo_unmarshaler(v, t); ok {
+			return value, err
+		}
 	}
 	if kind == reflect.Interface {
 		switch v.Type {
@@ -53,6 +94,9 @@ func valueToGo(v *proto.Value, t reflect.Type) (interface{}, error) {
 		case proto.Value_LIST:
 			kind = reflect.Slice
 
+		case proto.Value_DECIMAL:
+			return decimalToGo(v)
+
 		case proto.Value_NULL:
 			return sdk.Null, nil
 
@@ -95,26 +139,34 @@ func valueToGo(v *proto.Value, t reflect.Type) (interface{}, error) {
 		return convertValueBool(v)
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		v, err := convertValueInt64(v)
+		v, err := s.convertValueInt64(v)
 		if err != nil {
 			return v, err
 		}
 
+		if s.Strict && reflect.Zero(t).OverflowInt(v.(int64)) {
+			return nil, fmt.Errorf("integer %d overflows %s", v, t)
+		}
+
 		// This is pretty expensive but makes the implementation easy.
 		// The performance is likely to be overshadowed by the RPC cost
 		// and function cost itself.
 		return reflect.ValueOf(v).Convert(t).Interface(), nil
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		v, err := convertValueUint64(v)
+		v, err := s.convertValueUint64(v)
 		if err != nil {
 			return v, err
 		}
 
+		if s.Strict && reflect.Zero(t).OverflowUint(v.(uint64)) {
+			return nil, fmt.Errorf("integer %d overflows %s", v, t)
+		}
+
 		return reflect.ValueOf(v).Convert(t).Interface(), nil
 
 	case reflect.Float32:
-		v, err := convertValueFloat(v, 32)
+		v, err := s.convertValueFloat(v, 32)
 		if err != nil {
 			return v, err
 		}
@@ -122,16 +174,19 @@ func valueToGo(v *proto.Value, t reflect.Type) (interface{}, error) {
 		return float32(v.(float64)), nil
 
 	case reflect.Float64:
-		return convertValueFloat(v, 64)
+		return s.convertValueFloat(v, 64)
 
 	case reflect.String:
-		return convertValueString(v)
+		return s.convertValueString(v)
 
 	case reflect.Slice:
-		return convertValueSlice(v, t)
+		return s.convertValueSlice(v, t)
 
 	case reflect.Map:
-		return convertValueMap(v, t)
+		return s.convertValueMap(v, t)
+
+	case reflect.Struct:
+		return s.convertValueStruct(v, t)
 
 	case reflect.Ptr:
 		switch v.Type {
@@ -142,7 +197,7 @@ func valueToGo(v *proto.Value, t reflect.Type) (interface{}, error) {
 			return sdk.Undefined, nil
 		}
 
-		fallthrough
+		return s.convertValuePtr(v, t)
 
 	default:
 		return nil, convertErr(v, t.Kind().String())
@@ -157,22 +212,34 @@ func convertValueBool(raw *proto.Value) (interface{}, error) {
 	return nil, convertErr(raw, "bool")
 }
 
-func convertValueInt64(raw *proto.Value) (interface{}, error) {
-	switch raw.Type {
-	case proto.Value_INT:
+func (s *decodeState) convertValueInt64(raw *proto.Value) (interface{}, error) {
+	switch {
+	case raw.Type == proto.Value_INT:
 		return raw.Value.(*proto.Value_ValueInt).ValueInt, nil
 
-	case proto.Value_STRING:
+	case raw.Type == proto.Value_STRING && !s.Strict:
 		return strconv.ParseInt(raw.Value.(*proto.Value_ValueString).ValueString, 0, 64)
 
+	case raw.Type == proto.Value_DECIMAL:
+		i, err := decimalInt(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if !i.IsInt64() {
+			return nil, fmt.Errorf("decimal %s overflows int64", i)
+		}
+
+		return i.Int64(), nil
+
 	default:
 		return nil, convertErr(raw, "int")
 	}
 }
 
-func convertValueUint64(raw *proto.Value) (interface{}, error) {
-	switch raw.Type {
-	case proto.Value_INT:
+func (s *decodeState) convertValueUint64(raw *proto.Value) (interface{}, error) {
+	switch {
+	case raw.Type == proto.Value_INT:
 		value := raw.Value.(*proto.Value_ValueInt).ValueInt
 		if value < 0 {
 			return nil, fmt.Errorf(
@@ -181,64 +248,119 @@ func convertValueUint64(raw *proto.Value) (interface{}, error) {
 
 		return uint64(value), nil
 
-	case proto.Value_STRING:
+	case raw.Type == proto.Value_STRING && !s.Strict:
 		return strconv.ParseUint(raw.Value.(*proto.Value_ValueString).ValueString, 0, 64)
 
+	case raw.Type == proto.Value_DECIMAL:
+		i, err := decimalInt(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if !i.IsUint64() {
+			return nil, fmt.Errorf("decimal %s overflows uint64", i)
+		}
+
+		return i.Uint64(), nil
+
 	default:
 		return nil, convertErr(raw, "uint")
 	}
 }
 
-func convertValueFloat(raw *proto.Value, bitSize int) (interface{}, error) {
-	switch raw.Type {
-	case proto.Value_INT:
-		return float64(raw.Value.(*proto.Value_ValueInt).ValueInt), nil
+func (s *decodeState) convertValueFloat(raw *proto.Value, bitSize int) (interface{}, error) {
+	var f float64
+	switch {
+	case raw.Type == proto.Value_INT:
+		i := raw.Value.(*proto.Value_ValueInt).ValueInt
+		if s.Strict && (i > maxExactFloat || i < -maxExactFloat) {
+			return nil, fmt.Errorf("integer %d cannot be represented exactly as a float", i)
+		}
 
-	case proto.Value_FLOAT:
-		return raw.Value.(*proto.Value_ValueFloat).ValueFloat, nil
+		f = float64(i)
 
-	case proto.Value_STRING:
+	case raw.Type == proto.Value_FLOAT:
+		f = raw.Value.(*proto.Value_ValueFloat).ValueFloat
+
+	case raw.Type == proto.Value_STRING && !s.Strict:
 		return strconv.ParseFloat(raw.Value.(*proto.Value_ValueString).ValueString, bitSize)
 
+	case raw.Type == proto.Value_DECIMAL:
+		d, err := parseDecimalRat(raw.GetValueDecimal())
+		if err != nil {
+			return nil, err
+		}
+
+		var exact bool
+		f, exact = d.Float64()
+		if s.Strict && !exact {
+			return nil, fmt.Errorf("decimal %s cannot be represented exactly as a float", raw.GetValueDecimal())
+		}
+
 	default:
 		return nil, convertErr(raw, "float")
 	}
+
+	if s.Strict && bitSize == 32 && !math.IsNaN(f) && float64(float32(f)) != f {
+		return nil, fmt.Errorf("float %v cannot be represented exactly as float32", f)
+	}
+
+	return f, nil
 }
 
-func convertValueString(raw *proto.Value) (interface{}, error) {
-	switch raw.Type {
-	case proto.Value_INT:
+func (s *decodeState) convertValueString(raw *proto.Value) (interface{}, error) {
+	switch {
+	case raw.Type == proto.Value_INT && !s.Strict:
 		return strconv.FormatInt(raw.Value.(*proto.Value_ValueInt).ValueInt, 10), nil
 
-	case proto.Value_STRING:
+	case raw.Type == proto.Value_STRING:
 		return raw.Value.(*proto.Value_ValueString).ValueString, nil
 
+	case raw.Type == proto.Value_DECIMAL && !s.Strict:
+		return raw.GetValueDecimal(), nil
+
 	default:
 		return nil, convertErr(raw, "string")
 	}
 }
 
-func convertValueSlice(raw *proto.Value, t reflect.Type) (interface{}, error) {
+func (s *decodeState) convertValuePtr(raw *proto.Value, t reflect.Type) (interface{}, error) {
+	elem, err := s.valueToGo(raw, t.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	ptr := reflect.New(t.Elem())
+	ptr.Elem().Set(toReflect(elem, t.Elem()))
+	return ptr.Interface(), nil
+}
+
+func (s *decodeState) convertValueSlice(raw *proto.Value, t reflect.Type) (interface{}, error) {
 	if raw.Type != proto.Value_LIST {
 		return nil, convertErr(raw, "list")
 	}
 
 	list := raw.Value.(*proto.Value_ValueList).ValueList
+	if err := s.enter(len(list.Elems)); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
 	elemTyp := t.Elem()
 	sliceVal := reflect.MakeSlice(t, len(list.Elems), len(list.Elems))
 	for i, elt := range list.Elems {
-		v, err := valueToGo(elt, elemTyp)
+		v, err := s.valueToGo(elt, elemTyp)
 		if err != nil {
 			return nil, fmt.Errorf("element %d: %s", i, err)
 		}
 
-		sliceVal.Index(i).Set(reflect.ValueOf(v))
+		sliceVal.Index(i).Set(toReflect(v, elemTyp))
 	}
 
 	return sliceVal.Interface(), nil
 }
 
-func convertValueMap(raw *proto.Value, t reflect.Type) (interface{}, error) {
+func (s *decodeState) convertValueMap(raw *proto.Value, t reflect.Type) (interface{}, error) {
 	if raw.Type != proto.Value_MAP {
 		return nil, convertErr(raw, "map")
 	}
@@ -248,9 +370,14 @@ func convertValueMap(raw *proto.Value, t reflect.Type) (interface{}, error) {
 	}
 
 	m := raw.Value.(*proto.Value_ValueMap).ValueMap
+	if err := s.enter(len(m.Elems)); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
 	keyTyp := t.Key()
 	elemTyp := t.Elem()
-	if len(m.Elems) == 0 {
+	if len(m.Elems) == 0 && keyTyp.Kind() == reflect.Interface {
 		// as we have no elements, it is much safer to presume a key type of
 		// string to ensure safety when attempting to perform actions such
 		// as json marshalling
@@ -261,24 +388,249 @@ func convertValueMap(raw *proto.Value, t reflect.Type) (interface{}, error) {
 	mapVal := reflect.MakeMap(t)
 	for _, elt := range m.Elems {
 		// Convert the key
-		key, err := valueToGo(elt.Key, keyTyp)
+		key, err := s.valueToGo(elt.Key, keyTyp)
 		if err != nil {
 			return nil, fmt.Errorf("key %s: %s", elt.Key.String(), err)
 		}
 
 		// Convert the value
-		elem, err := valueToGo(elt.Value, elemTyp)
+		elem, err := s.valueToGo(elt.Value, elemTyp)
 		if err != nil {
 			return nil, fmt.Errorf("element for key %s: %s", elt.Key.String(), err)
 		}
 
+		// Lists and maps can't be Go map keys
+		keyVal := toReflect(key, keyTyp)
+		if !keyVal.Comparable() {
+			return nil, fmt.Errorf("key %s: cannot use %s as a map key", elt.Key.String(), elt.Key.Type)
+		}
+
 		// Set it
-		mapVal.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(elem))
+		mapVal.SetMapIndex(keyVal, toReflect(elem, elemTyp))
 	}
 
 	return mapVal.Interface(), nil
 }
 
+func (s *decodeState) convertValueStruct(raw *proto.Value, t reflect.Type) (interface{}, error) {
+	if raw.Type != proto.Value_MAP {
+		return nil, convertErr(raw, "struct")
+	}
+
+	fields := make(map[string]decodeField)
+	if err := s.decodeFields(t, nil, 0, map[reflect.Type]bool{t: true}, fields); err != nil {
+		return nil, err
+	}
+
+	m := raw.Value.(*proto.Value_ValueMap).ValueMap
+	if err := s.enter(len(m.Elems)); err != nil {
+		return nil, err
+	}
+	defer s.leave()
+
+	structVal := reflect.New(t).Elem()
+	for _, elt := range m.Elems {
+		// Only string keys can match a field
+		var key string
+		var field decodeField
+		var ok bool
+		if elt.Key.Type == proto.Value_STRING {
+			key = elt.Key.GetValueString()
+			field, ok = fields[key]
+		}
+
+		if !ok {
+			if s.DisallowUnknownFields {
+				return nil, fmt.Errorf("unknown field for key %s", elt.Key.String())
+			}
+
+			continue
+		}
+
+		fieldVal, err := fieldByIndex(structVal, field.index)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s", key, err)
+		}
+
+		value := elt.Value
+		if field.asString {
+			value, err = parseStringValue(value, fieldVal.Type())
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %s", key, err)
+			}
+		}
+
+		elem, err := s.valueToGo(value, fieldVal.Type())
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s", key, err)
+		}
+
+		fieldVal.Set(toReflect(elem, fieldVal.Type()))
+	}
+
+	return structVal.Interface(), nil
+}
+
+// decodeField is a struct field that a map key is decoded into.
+type decodeField struct {
+	index    []int // index sequence for reflect.Value.FieldByIndex
+	depth    int   // nesting depth for inlined fields
+	asString bool
+}
+
+// decodeFields adds the fields of struct type t to fields, keyed by map
+// key. Duplicate keys from inlined structs are resolved the same way as
+// in GoToValue. The types being inlined are tracked in inlining, as in
+// StructFields, so that a struct inlining itself is only flattened once.
+func (s *decodeState) decodeFields(t reflect.Type, index []int, depth int, inlining map[reflect.Type]bool, fields map[string]decodeField) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, err := parseStructTag(field)
+		if field.PkgPath != "" && !(field.Anonymous && tag.inline) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if tag.skip {
+			continue
+		}
+
+		fieldIndex := append(append([]int(nil), index...), i)
+		if tag.inline {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+
+			if ft.Kind() != reflect.Struct {
+				return fmt.Errorf(
+					"field %s: cannot inline %s, only structs can be inlined",
+					field.Name, ft.Kind())
+			}
+
+			if inlining[ft] {
+				continue
+			}
+
+			inlining[ft] = true
+			err := s.decodeFields(ft, fieldIndex, depth+1, inlining, fields)
+			delete(inlining, ft)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		key := tag.name
+		if key == "" {
+			key = s.fieldName(field.Name)
+		}
+
+		if existing, ok := fields[key]; ok && existing.depth <= depth {
+			continue
+		}
+
+		fields[key] = decodeField{index: fieldIndex, depth: depth, asString: tag.asString}
+	}
+
+	return nil
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex, allocating any nil embedded
+// pointers along the way.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					if !v.CanSet() {
+						return v, fmt.Errorf(
+							"cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+					}
+
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+
+				v = v.Elem()
+			}
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
+
+// parseStringValue parses a string value for a field with the "string"
+// tag option into a value of the kind of t. Values that aren't strings,
+// and types other than booleans and numbers, are returned as-is.
+func parseStringValue(raw *proto.Value, t reflect.Type) (*proto.Value, error) {
+	if raw.Type != proto.Value_STRING {
+		return raw, nil
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	str := raw.GetValueString()
+	switch t.Kind() {
+	case reflect.Bool:
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, err
+		}
+
+		return &proto.Value{
+			Type:  proto.Value_BOOL,
+			Value: &proto.Value_ValueBool{ValueBool: v},
+		}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return intValue(v), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(str, 10, 63)
+		if err != nil {
+			return nil, err
+		}
+
+		return intValue(int64(v)), nil
+
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return floatValue(v), nil
+
+	default:
+		return raw, nil
+	}
+}
+
+// toReflect returns the reflect.Value of v to set in a value of type t.
+// Null and undefined can only be stored in interface types, for any other
+// type they result in the zero value, as does a nil v.
+func toReflect(v interface{}, t reflect.Type) reflect.Value {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || ((v == sdk.Null || v == sdk.Undefined) && !rv.Type().AssignableTo(t)) {
+		return reflect.Zero(t)
+	}
+
+	return rv
+}
+
 // valueMapType creates a map type to match the keys/values in the value.
 func valueMapType(raw *proto.Value) reflect.Type {
 	m := raw.Value.(*proto.Value_ValueMap).ValueMap
@@ -0,0 +1,295 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package encoding
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// Types used for the conversion of well-known types. See the package
+// documentation for how these are converted.
+var (
+	timeTyp     = reflect.TypeOf(time.Time{})
+	durationTyp = reflect.TypeOf(time.Duration(0))
+	ipTyp       = reflect.TypeOf(net.IP{})
+	urlTyp      = reflect.TypeOf(url.URL{})
+	bigIntTyp   = reflect.TypeOf(big.Int{})
+	bigFloatTyp = reflect.TypeOf(big.Float{})
+	bigRatTyp   = reflect.TypeOf(big.Rat{})
+	bytesTyp    = reflect.TypeOf([]byte{})
+)
+
+// wellKnownToValue are the conversions of well-known types to values,
+// keyed by type.
+var wellKnownToValue = map[reflect.Type]func(reflect.Value) (*proto.Value, error){
+	timeTyp: func(v reflect.Value) (*proto.Value, error) {
+		return stringValue(v.Interface().(time.Time).Format(time.RFC3339Nano)), nil
+	},
+
+	durationTyp: func(v reflect.Value) (*proto.Value, error) {
+		return intValue(v.Int()), nil
+	},
+
+	ipTyp: func(v reflect.Value) (*proto.Value, error) {
+		if v.IsNil() {
+			return &proto.Value{Type: proto.Value_NULL}, nil
+		}
+
+		return stringValue(v.Interface().(net.IP).String()), nil
+	},
+
+	urlTyp: func(v reflect.Value) (*proto.Value, error) {
+		u := v.Interface().(url.URL)
+		return stringValue(u.String()), nil
+	},
+
+	bigIntTyp: func(v reflect.Value) (*proto.Value, error) {
+		i := addr(v).Interface().(*big.Int)
+		if i.IsInt64() {
+			return intValue(i.Int64()), nil
+		}
+
+		return stringValue(i.String()), nil
+	},
+
+	bigFloatTyp: func(v reflect.Value) (*proto.Value, error) {
+		f := addr(v).Interface().(*big.Float)
+		if f64, acc := f.Float64(); acc == big.Exact {
+			return floatValue(f64), nil
+		}
+
+		return stringValue(f.Text('g', -1)), nil
+	},
+
+	bigRatTyp: func(v reflect.Value) (*proto.Value, error) {
+		// The format of big.Rat.MarshalText
+		return stringValue(addr(v).Interface().(*big.Rat).RatString()), nil
+	},
+}
+
+// toValue_bytes converts a []byte to a string of its standard base64
+// encoding, for an Encoder with Base64Bytes set.
+func toValue_bytes(v reflect.Value) *proto.Value {
+	if v.IsNil() {
+		return &proto.Value{Type: proto.Value_NULL}
+	}
+
+	return stringValue(base64.StdEncoding.EncodeToString(v.Bytes()))
+}
+
+// wellKnownToGo are the conversions of values to well-known types, keyed
+// by type.
+var wellKnownToGo = map[reflect.Type]func(*proto.Value) (interface{}, error){
+	timeTyp: func(raw *proto.Value) (interface{}, error) {
+		switch raw.Type {
+		case proto.Value_STRING:
+			return time.Parse(time.RFC3339Nano, raw.GetValueString())
+
+		case proto.Value_INT:
+			return time.Unix(raw.GetValueInt(), 0).UTC(), nil
+
+		default:
+			return nil, convertErr(raw, "time")
+		}
+	},
+
+	durationTyp: func(raw *proto.Value) (interface{}, error) {
+		switch raw.Type {
+		case proto.Value_STRING:
+			return time.ParseDuration(raw.GetValueString())
+
+		case proto.Value_INT:
+			return time.Duration(raw.GetValueInt()), nil
+
+		default:
+			return nil, convertErr(raw, "duration")
+		}
+	},
+
+	ipTyp: func(raw *proto.Value) (interface{}, error) {
+		if raw.Type != proto.Value_STRING {
+			return nil, convertErr(raw, "IP")
+		}
+
+		ip := net.ParseIP(raw.GetValueString())
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address: %q", raw.GetValueString())
+		}
+
+		return ip, nil
+	},
+
+	urlTyp: func(raw *proto.Value) (interface{}, error) {
+		if raw.Type != proto.Value_STRING {
+			return nil, convertErr(raw, "URL")
+		}
+
+		u, err := url.Parse(raw.GetValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		return *u, nil
+	},
+
+	bigIntTyp: func(raw *proto.Value) (interface{}, error) {
+		var i big.Int
+		switch raw.Type {
+		case proto.Value_INT:
+			i.SetInt64(raw.GetValueInt())
+
+		case proto.Value_STRING:
+			if _, ok := i.SetString(raw.GetValueString(), 0); !ok {
+				return nil, fmt.Errorf("invalid integer: %q", raw.GetValueString())
+			}
+
+		case proto.Value_FLOAT:
+			f := big.NewFloat(raw.GetValueFloat())
+			if !f.IsInt() {
+				return nil, fmt.Errorf(
+					"cannot convert float with fractional part to integer: %v",
+					raw.GetValueFloat())
+			}
+
+			f.Int(&i)
+
+		case proto.Value_DECIMAL:
+			d, err := decimalInt(raw)
+			if err != nil {
+				return nil, err
+			}
+
+			i.Set(d)
+
+		default:
+			return nil, convertErr(raw, "big integer")
+		}
+
+		return i, nil
+	},
+
+	bigFloatTyp: func(raw *proto.Value) (interface{}, error) {
+		var f big.Float
+		switch raw.Type {
+		case proto.Value_INT:
+			f.SetInt64(raw.GetValueInt())
+
+		case proto.Value_FLOAT:
+			f.SetFloat64(raw.GetValueFloat())
+
+		case proto.Value_STRING:
+			if _, ok := f.SetString(raw.GetValueString()); !ok {
+				return nil, fmt.Errorf("invalid float: %q", raw.GetValueString())
+			}
+
+		case proto.Value_DECIMAL:
+			d, err := parseDecimal(raw.GetValueDecimal())
+			if err != nil {
+				return nil, err
+			}
+
+			f.Set(d)
+
+		default:
+			return nil, convertErr(raw, "big float")
+		}
+
+		return f, nil
+	},
+
+	bigRatTyp: func(raw *proto.Value) (interface{}, error) {
+		var r big.Rat
+		switch raw.Type {
+		case proto.Value_INT:
+			r.SetInt64(raw.GetValueInt())
+
+		case proto.Value_FLOAT:
+			if r.SetFloat64(raw.GetValueFloat()) == nil {
+				return nil, fmt.Errorf("cannot convert %v to rational", raw.GetValueFloat())
+			}
+
+		case proto.Value_DECIMAL:
+			d, err := parseDecimalRat(raw.GetValueDecimal())
+			if err != nil {
+				return nil, err
+			}
+
+			r.Set(d)
+
+		case proto.Value_STRING:
+			if _, ok := r.SetString(raw.GetValueString()); !ok {
+				return nil, fmt.Errorf("invalid rational: %q", raw.GetValueString())
+			}
+
+		default:
+			return nil, convertErr(raw, "big rational")
+		}
+
+		return r, nil
+	},
+
+	bytesTyp: func(raw *proto.Value) (interface{}, error) {
+		switch raw.Type {
+		case proto.Value_STRING:
+			return base64.StdEncoding.DecodeString(raw.GetValueString())
+
+		case proto.Value_LIST:
+			elems := raw.GetValueList().GetElems()
+			result := make([]byte, len(elems))
+			for i, elem := range elems {
+				if elem.Type != proto.Value_INT || elem.GetValueInt() < 0 || elem.GetValueInt() > 255 {
+					return nil, fmt.Errorf("element %d: %s", i, convertErr(elem, "byte"))
+				}
+
+				result[i] = byte(elem.GetValueInt())
+			}
+
+			return result, nil
+
+		default:
+			return nil, convertErr(raw, "bytes")
+		}
+	},
+}
+
+// addr returns a pointer to the value, copying it if it isn't
+// addressable. This is used to call pointer methods.
+func addr(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr
+}
+
+func intValue(v int64) *proto.Value {
+	return &proto.Value{
+		Type:  proto.Value_INT,
+		Value: &proto.Value_ValueInt{ValueInt: v},
+	}
+}
+
+func floatValue(v float64) *proto.Value {
+	return &proto.Value{
+		Type:  proto.Value_FLOAT,
+		Value: &proto.Value_ValueFloat{ValueFloat: v},
+	}
+}
+
+func stringValue(v string) *proto.Value {
+	return &proto.Value{
+		Type:  proto.Value_STRING,
+		Value: &proto.Value_ValueString{ValueString: v},
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var errorTyp = reflect.TypeOf((*error)(nil)).Elem()
+
+// NamespaceBuilder declares the keys of a namespace one by one, as an
+// alternative to implementing Get, Map and Func with switch statements
+// and a list of keys for MapFromKeys that must all be kept in sync.
+//
+//	ns := framework.NewNamespace().
+//	    Value("name", func() (interface{}, error) { return r.name, nil }).
+//	    Func("lookup", r.lookup).
+//	    Namespace("sub", sub).
+//	    Build()
+//
+// The methods declaring keys panic if the key is already declared or the
+// function is invalid, since these are programming errors.
+type NamespaceBuilder struct {
+	keys    []string
+	entries map[string]*builderEntry
+}
+
+// NewNamespace returns a NamespaceBuilder with no keys.
+func NewNamespace() *NamespaceBuilder {
+	return &NamespaceBuilder{entries: make(map[string]*builderEntry)}
+}
+
+// Value declares a key whose value is returned by get. The function is
+// called each time the key is requested, including for Map.
+func (b *NamespaceBuilder) Value(key string, get func() (interface{}, error)) *NamespaceBuilder {
+	if get == nil {
+		panic(fmt.Sprintf("framework: nil getter for key %q", key))
+	}
+
+	return b.add(key, &builderEntry{kind: KeyValue, get: get})
+}
+
+// Static declares a key with a fixed value.
+func (b *NamespaceBuilder) Static(key string, v interface{}) *NamespaceBuilder {
+	return b.Value(key, func() (interface{}, error) { return v, nil })
+}
+
+// Func declares a function that can be called with the key. The function
+// follows the rules of Call.Func: it may take any number of arguments and
+// must return either (interface{}, error) or a single value. It may also
+// be a TypedFunc.
+func (b *NamespaceBuilder) Func(key string, fn interface{}) *NamespaceBuilder {
+	if _, ok := fn.(TypedFunc); !ok {
+		if err := validateFunc(reflect.TypeOf(fn)); err != nil {
+			panic(fmt.Sprintf("framework: invalid function for key %q: %s", key, err))
+		}
+	}
+
+	return b.add(key, &builderEntry{kind: KeyFunc, fn: fn})
+}
+
+// Namespace declares a nested namespace.
+func (b *NamespaceBuilder) Namespace(key string, ns Namespace) *NamespaceBuilder {
+	if ns == nil {
+		panic(fmt.Sprintf("framework: nil namespace for key %q", key))
+	}
+
+	return b.add(key, &builderEntry{kind: KeyNamespace, ns: ns})
+}
+
+// Memo sets the memoization policy of a declared key, see Memoize.
+func (b *NamespaceBuilder) Memo(key string, m Memo) *NamespaceBuilder {
+	e, ok := b.entries[key]
+	if !ok {
+		panic(fmt.Sprintf("framework: memo for undeclared key %q", key))
+	}
+
+	// Entries are shared with namespaces already built
+	copied := *e
+	copied.memo = m
+	b.entries[key] = &copied
+	return b
+}
+
+// Build returns the namespace with the keys declared so far. The builder
+// may be used to declare further keys afterwards, without affecting the
+// namespace returned.
+func (b *NamespaceBuilder) Build() *BuiltNamespace {
+	ns := &BuiltNamespace{
+		keys:    make([]string, len(b.keys)),
+		entries: make(map[string]*builderEntry, len(b.entries)),
+	}
+	copy(ns.keys, b.keys)
+	for k, e := range b.entries {
+		ns.entries[k] = e
+		if e.memo != MemoDefault {
+			if ns.memo == nil {
+				ns.memo = make(map[string]Memo)
+			}
+
+			ns.memo[k] = e.memo
+		}
+	}
+
+	return ns
+}
+
+func (b *NamespaceBuilder) add(key string, e *builderEntry) *NamespaceBuilder {
+	if _, ok := b.entries[key]; ok {
+		panic(fmt.Sprintf("framework: key %q declared more than once", key))
+	}
+
+	b.keys = append(b.keys, key)
+	b.entries[key] = e
+	return b
+}
+
+// validateFunc returns an error if t is not a function that can be
+// returned from Call.Func.
+func validateFunc(t reflect.Type) error {
+	if t == nil || t.Kind() != reflect.Func {
+		return fmt.Errorf("expected a function, got %v", t)
+	}
+
+	if t.IsVariadic() {
+		return fmt.Errorf("variadic functions are not supported")
+	}
+
+	switch {
+	case t.NumOut() == 1:
+	case t.NumOut() == 2 && t.Out(1) == errorTyp:
+	default:
+		return fmt.Errorf("function must return a value and optionally an error, returns %d values", t.NumOut())
+	}
+
+	return nil
+}
+
+// BuiltNamespace is a namespace declared with a NamespaceBuilder. It
+// implements Namespace, Map, Call, Has, Keys and Memoize.
+//
+// Map returns every value, as well as every nested namespace that
+// implements Map or List, except for keys with the MemoLazy policy.
+// Functions and other nested namespaces can only be reached by a key,
+// unless they have the MemoFlatten policy.
+type BuiltNamespace struct {
+	keys    []string
+	entries map[string]*builderEntry
+	memo    map[string]Memo
+}
+
+// builderEntry is a declared key of a namespace. Only the field for its
+// kind is set.
+type builderEntry struct {
+	kind KeyKind
+	get  func() (interface{}, error)
+	fn   interface{}
+	ns   Namespace
+	memo Memo
+}
+
+// KeyKind is the kind of a key declared with a NamespaceBuilder.
+type KeyKind int
+
+const (
+	KeyValue     KeyKind = iota + 1 // a value, see NamespaceBuilder.Value
+	KeyFunc                         // a function, see NamespaceBuilder.Func
+	KeyNamespace                    // a nested namespace
+)
+
+func (k KeyKind) String() string {
+	switch k {
+	case KeyValue:
+		return "value"
+	case KeyFunc:
+		return "func"
+	case KeyNamespace:
+		return "namespace"
+	default:
+		return fmt.Sprintf("KeyKind(%d)", int(k))
+	}
+}
+
+// KeyInfo describes a key of a BuiltNamespace.
+type KeyInfo struct {
+	Key  string
+	Kind KeyKind
+
+	// Func is the type of the function, for keys of kind KeyFunc.
+	Func reflect.Type
+
+	// Keys are the keys of a nested namespace, for keys of kind
+	// KeyNamespace where the namespace is also a BuiltNamespace.
+	Keys []KeyInfo
+}
+
+// Get implements Namespace.
+func (ns *BuiltNamespace) Get(key string) (interface{}, error) {
+	e, ok := ns.entries[key]
+	if !ok {
+		return nil, nil
+	}
+
+	switch e.kind {
+	case KeyValue:
+		return e.get()
+
+	case KeyNamespace:
+		return e.ns, nil
+
+	default:
+		// Functions only have a value when called
+		return nil, nil
+	}
+}
+
+// Map implements Map.
+func (ns *BuiltNamespace) Map() (map[string]interface{}, error) {
+	keys := make([]string, 0, len(ns.keys))
+	for _, k := range ns.keys {
+		e := ns.entries[k]
+		if e.memo == MemoLazy {
+			continue
+		}
+
+		switch e.kind {
+		case KeyValue:
+			keys = append(keys, k)
+
+		case KeyNamespace:
+			switch e.ns.(type) {
+			case Map, List:
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	return MapFromKeys(ns, keys)
+}
+
+// Memoize implements Memoize, returning the policies set with
+// NamespaceBuilder.Memo.
+func (ns *BuiltNamespace) Memoize() map[string]Memo {
+	return ns.memo
+}
+
+// Func implements Call.
+func (ns *BuiltNamespace) Func(key string) interface{} {
+	if e, ok := ns.entries[key]; ok && e.kind == KeyFunc {
+		return e.fn
+	}
+
+	return nil
+}
+
+// Has implements Has. Keys of functions only have a value when called, so
+// the namespace doesn't have them.
+func (ns *BuiltNamespace) Has(key string) (bool, error) {
+	e, ok := ns.entries[key]
+	return ok && e.kind != KeyFunc, nil
+}
+
+// Keys implements Keys, returning the declared keys in the order they were
+// declared.
+func (ns *BuiltNamespace) Keys() []string {
+	result := make([]string, len(ns.keys))
+	copy(result, ns.keys)
+	return result
+}
+
+// Schema describes the declared keys in the order they were declared,
+// including the keys of nested namespaces that are BuiltNamespaces.
+func (ns *BuiltNamespace) Schema() []KeyInfo {
+	result := make([]KeyInfo, len(ns.keys))
+	for i, k := range ns.keys {
+		e := ns.entries[k]
+		result[i] = KeyInfo{Key: k, Kind: e.kind}
+		switch e.kind {
+		case KeyFunc:
+			result[i].Func = reflect.TypeOf(e.fn)
+
+		case KeyNamespace:
+			if sub, ok := e.ns.(*BuiltNamespace); ok {
+				result[i].Keys = sub.Schema()
+			}
+		}
+	}
+
+	return result
+}
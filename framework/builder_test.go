@@ -0,0 +1,243 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestBuiltNamespace_impl(t *testing.T) {
+	var _ Map = new(BuiltNamespace)
+	var _ Call = new(BuiltNamespace)
+}
+
+// rootBuiltNamespace embeds a BuiltNamespace as a plugin root.
+type rootBuiltNamespace struct{ *BuiltNamespace }
+
+func (r *rootBuiltNamespace) Configure(map[string]interface{}) error { return nil }
+
+func testBuiltNamespace() *BuiltNamespace {
+	sub := NewNamespace().
+		Static("hour", 12).
+		Build()
+
+	return NewNamespace().
+		Static("name", "foo").
+		Value("count", func() (interface{}, error) { return 42, nil }).
+		Func("add", func(a, b int) (interface{}, error) { return a + b, nil }).
+		Namespace("time", sub).
+		Namespace("opaque", &nsKeyValue{Key: "key", Value: "value"}).
+		Build()
+}
+
+func TestBuiltNamespace_get(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Keys     []sdk.GetKey
+		Expected interface{}
+	}{
+		{
+			"static value",
+			[]sdk.GetKey{{Key: "name"}},
+			"foo",
+		},
+
+		{
+			"getter value",
+			[]sdk.GetKey{{Key: "count"}},
+			42,
+		},
+
+		{
+			"function call",
+			[]sdk.GetKey{{Key: "add", Args: []interface{}{1, 2}}},
+			3,
+		},
+
+		{
+			"function without call",
+			[]sdk.GetKey{{Key: "add"}},
+			sdk.Undefined,
+		},
+
+		{
+			"nested value",
+			[]sdk.GetKey{{Key: "time"}, {Key: "hour"}},
+			12,
+		},
+
+		{
+			"nested map",
+			[]sdk.GetKey{{Key: "time"}},
+			map[string]interface{}{"hour": 12},
+		},
+
+		{
+			"nested namespace without map",
+			[]sdk.GetKey{{Key: "opaque"}, {Key: "key"}},
+			"value",
+		},
+
+		{
+			"unknown key",
+			[]sdk.GetKey{{Key: "unknown"}},
+			sdk.Undefined,
+		},
+
+		{
+			"whole namespace",
+			nil,
+			map[string]interface{}{
+				"name":  "foo",
+				"count": 42,
+				"time":  map[string]interface{}{"hour": 12},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: &rootBuiltNamespace{testBuiltNamespace()}}
+			results, err := p.Get([]*sdk.GetReq{{Keys: tc.Keys}})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestBuiltNamespace_getError(t *testing.T) {
+	ns := NewNamespace().
+		Value("fail", func() (interface{}, error) { return nil, errors.New("failed") }).
+		Build()
+
+	if _, err := ns.Get("fail"); err == nil || err.Error() != "failed" {
+		t.Fatalf("expected error, got: %v", err)
+	}
+
+	if _, err := ns.Map(); err == nil {
+		t.Fatal("expected error from Map")
+	}
+}
+
+func TestBuiltNamespace_schema(t *testing.T) {
+	ns := testBuiltNamespace()
+
+	expectedKeys := []string{"name", "count", "add", "time", "opaque"}
+	if actual := ns.Keys(); !reflect.DeepEqual(actual, expectedKeys) {
+		t.Fatalf("expected %v, got %v", expectedKeys, actual)
+	}
+
+	expected := []KeyInfo{
+		{Key: "name", Kind: KeyValue},
+		{Key: "count", Kind: KeyValue},
+		{
+			Key:  "add",
+			Kind: KeyFunc,
+			Func: reflect.TypeOf(func(a, b int) (interface{}, error) { return nil, nil }),
+		},
+		{
+			Key:  "time",
+			Kind: KeyNamespace,
+			Keys: []KeyInfo{{Key: "hour", Kind: KeyValue}},
+		},
+		{Key: "opaque", Kind: KeyNamespace},
+	}
+	if actual := ns.Schema(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestNamespaceBuilder_build(t *testing.T) {
+	b := NewNamespace().Static("a", 1)
+	ns := b.Build()
+	b.Static("b", 2)
+
+	// Keys declared after Build don't affect the namespace
+	if actual := ns.Keys(); !reflect.DeepEqual(actual, []string{"a"}) {
+		t.Fatalf("bad: %v", actual)
+	}
+}
+
+func TestNamespaceBuilder_panics(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Build func()
+		Panic string
+	}{
+		{
+			"duplicate key",
+			func() { NewNamespace().Static("a", 1).Func("a", func() interface{} { return nil }) },
+			`key "a" declared more than once`,
+		},
+
+		{
+			"not a function",
+			func() { NewNamespace().Func("a", 42) },
+			`invalid function for key "a": expected a function, got int`,
+		},
+
+		{
+			"nil function",
+			func() { NewNamespace().Func("a", nil) },
+			`invalid function for key "a": expected a function, got <nil>`,
+		},
+
+		{
+			"no return values",
+			func() { NewNamespace().Func("a", func() {}) },
+			"returns 0 values",
+		},
+
+		{
+			"second return value not an error",
+			func() { NewNamespace().Func("a", func() (int, int) { return 0, 0 }) },
+			"returns 2 values",
+		},
+
+		{
+			"variadic function",
+			func() { NewNamespace().Func("a", func(...int) int { return 0 }) },
+			"variadic functions are not supported",
+		},
+
+		{
+			"nil getter",
+			func() { NewNamespace().Value("a", nil) },
+			`nil getter for key "a"`,
+		},
+
+		{
+			"nil namespace",
+			func() { NewNamespace().Namespace("a", nil) },
+			`nil namespace for key "a"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("expected panic")
+				}
+
+				if msg, _ := r.(string); !strings.Contains(msg, tc.Panic) {
+					t.Fatalf("expected panic containing %q, got: %v", tc.Panic, r)
+				}
+			}()
+
+			tc.Build()
+		})
+	}
+}
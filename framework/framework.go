@@ -39,18 +39,39 @@
 // implement the optional Call or Map interfaces, to support function
 // calls or selective memoization calls, respectively.
 //
+// Rather than implementing these interfaces by hand, a namespace can be
+// declared key by key with NewNamespace, which returns a namespace
+// implementing Namespace, Map and Call consistently:
+//
+//	ns := framework.NewNamespace().
+//	    Static("version", "1.0").
+//	    Func("lookup", lookup).
+//	    Namespace("sub", sub).
+//	    Build()
+//
+// An existing Go type can also be exposed without writing a namespace
+// with NewStructNamespace, which turns exported fields into keys and
+// exported methods into functions.
+//
+// Plugin.Configure checks the structure of the plugin with Validate, so
+// that invalid functions in namespaces implementing Schema or Keys, such
+// as those built with NewNamespace, are reported before any policy is
+// executed. Namespaces implementing neither are logged as unchecked.
+//
 // Root namespaces are generally global, that is, for the lifetime of
 // the execution of Sentinel, one single plugin Root namespace state
 // will be shared by all policies that need to be executed. Take care
 // when storing state in the Root namespace. If you require state
 // in the Root namespace that must be unique across policy
-// executions, implement the NamespaceCreator interface.
+// executions, implement the NamespaceCreator interface. Namespaces
+// created this way may implement Closer to release their resources once
+// the execution is over.
 //
 // The Root namespace (or the NamespaceCreator interface, which
 // embeds Root) may optionally implement the New interface, which
 // allows for the construction of namespaces via the handling of
-// arbitrary object data. New is ignored for namespaces past the
-// root.
+// arbitrary object data. Nested namespaces may implement New too,
+// and NewValue supports receivers that aren't objects, such as lists.
 //
 // Non-primitive plugin return data is normally memoized, including
 // for namespaces. This prevents expensive calls over the plugin RPC.
@@ -65,11 +86,26 @@
 // a single expression, it does not matter if baz is excluded from
 // Map.
 //
+// * Implementing the Keys interface instead has the framework build
+// the map with Get for each key, only when the namespace itself is
+// requested.
+//
+// * Implementing the Memoize interface declares a policy for each
+// key: MemoFlatten and MemoLazy always include or leave out the key
+// when the namespace is returned as a whole, and MemoExec and
+// MemoGlobal cache the value of the key for the policy execution or
+// the lifetime of the plugin. NewNamespace and NewStructNamespace
+// support these policies too.
+//
 // * Struct memoization is implicit otherwise. Only exported fields
-// are acted on - fields are lower and snake cased where applicable.
-// To control this behavior, you can use the "sentinel" struct tag.
+// are acted on - fields are lower and snake cased where applicable,
+// see Plugin.FieldNaming to alter this. To control this behavior
+// per field, you can use the "sentinel" struct tag.
 // sentinel:"NAME" will alter the field to have the name indicated by
-// NAME, while an empty string will exclude the field.
+// NAME, while an empty string or "-" will exclude the field. The name
+// may be followed by the options "omitempty", "inline" and "string",
+// which behave as they do for encoding/json, with "inline" used to
+// flatten the fields of an embedded struct into the parent.
 //
 // Additionally, there are a couple of nuances that the plugin author
 // should be cognizant of:
@@ -77,7 +113,8 @@
 // * nil values within slices, maps, and structs are converted to
 // nulls in the return object.
 //
-// * Returning a nil from a Get call is undefined, not null.
+// * Returning a nil from a Get call is undefined, not null, unless
+// the namespace implements Has and reports that it has the key.
 //
 // The author can alter this behavior explicitly by assigning or
 // returning the sdk.Null and sdk.Undefined values.
@@ -0,0 +1,189 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"fmt"
+	"reflect"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/encoding"
+)
+
+// TypedFunc is a function whose signature is checked at compile time,
+// created with Func0, Func1, Func2 or Func3. It can be returned from
+// Call.Func like any other function, but calling it doesn't require
+// reflecting on the function.
+//
+//	func (ns *namespace) Func(key string) interface{} {
+//	    switch key {
+//	    case "lookup":
+//	        return framework.Func1(ns.lookup) // func(string) (*Record, error)
+//	    }
+//
+//	    return nil
+//	}
+type TypedFunc interface {
+	// NumArgs returns the number of arguments the function takes.
+	NumArgs() int
+
+	// Call calls the function, converting the arguments to the types
+	// the function expects.
+	Call(args []interface{}) (interface{}, error)
+}
+
+// Func0 returns a TypedFunc for a function without arguments.
+func Func0[R any](f func() (R, error)) TypedFunc {
+	return func0[R](f)
+}
+
+// Func1 returns a TypedFunc for a function with one argument.
+func Func1[A, R any](f func(A) (R, error)) TypedFunc {
+	return func1[A, R](f)
+}
+
+// Func2 returns a TypedFunc for a function with two arguments.
+func Func2[A, B, R any](f func(A, B) (R, error)) TypedFunc {
+	return func2[A, B, R](f)
+}
+
+// Func3 returns a TypedFunc for a function with three arguments.
+func Func3[A, B, C, R any](f func(A, B, C) (R, error)) TypedFunc {
+	return func3[A, B, C, R](f)
+}
+
+type func0[R any] func() (R, error)
+
+func (f func0[R]) NumArgs() int { return 0 }
+
+func (f func0[R]) Call(args []interface{}) (interface{}, error) {
+	if err := checkNumArgs(args, 0); err != nil {
+		return nil, err
+	}
+
+	return f()
+}
+
+type func1[A, R any] func(A) (R, error)
+
+func (f func1[A, R]) NumArgs() int { return 1 }
+
+func (f func1[A, R]) Call(args []interface{}) (interface{}, error) {
+	if err := checkNumArgs(args, 1); err != nil {
+		return nil, err
+	}
+
+	a, err := convertArg[A](args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return f(a)
+}
+
+type func2[A, B, R any] func(A, B) (R, error)
+
+func (f func2[A, B, R]) NumArgs() int { return 2 }
+
+func (f func2[A, B, R]) Call(args []interface{}) (interface{}, error) {
+	if err := checkNumArgs(args, 2); err != nil {
+		return nil, err
+	}
+
+	a, err := convertArg[A](args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := convertArg[B](args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return f(a, b)
+}
+
+type func3[A, B, C, R any] func(A, B, C) (R, error)
+
+func (f func3[A, B, C, R]) NumArgs() int { return 3 }
+
+func (f func3[A, B, C, R]) Call(args []interface{}) (interface{}, error) {
+	if err := checkNumArgs(args, 3); err != nil {
+		return nil, err
+	}
+
+	a, err := convertArg[A](args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := convertArg[B](args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := convertArg[C](args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return f(a, b, c)
+}
+
+func checkNumArgs(args []interface{}, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d arguments, got %d", n, len(args))
+	}
+
+	return nil
+}
+
+// convertArg converts an argument to type T. Arguments that already have
+// the type are used directly, which is the common case. Null and
+// undefined arguments are the zero value of T, such as a nil pointer.
+func convertArg[T any](arg interface{}) (T, error) {
+	if v, ok := arg.(T); ok {
+		return v, nil
+	}
+
+	var zero T
+	if arg == sdk.Null || arg == sdk.Undefined {
+		return zero, nil
+	}
+
+	t := reflect.TypeOf(&zero).Elem()
+	v, err := convertArgValue(arg, t)
+	if err != nil {
+		return zero, err
+	}
+
+	if v == nil {
+		// The zero value of an interface or pointer type
+		return zero, nil
+	}
+
+	result, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("error converting argument to %s: got %T", t, v)
+	}
+
+	return result, nil
+}
+
+// convertArgValue converts an argument that isn't assignable to type t by
+// converting it to a Sentinel value and back. This is slow, but it is
+// expected to be rare.
+func convertArgValue(arg interface{}, t reflect.Type) (interface{}, error) {
+	v, err := encoding.GoToValue(arg)
+	if err != nil {
+		return nil, fmt.Errorf("error converting argument to %s: %s", t, err)
+	}
+
+	result, err := encoding.ValueToGo(v, t)
+	if err != nil {
+		return nil, fmt.Errorf("error converting argument to %s: %s", t, err)
+	}
+
+	return result, nil
+}
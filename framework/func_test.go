@@ -0,0 +1,229 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestTypedFunc_call(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Func        TypedFunc
+		Args        []interface{}
+		Expected    interface{}
+		ExpectedErr string
+	}{
+		{
+			"no arguments",
+			Func0(func() (string, error) { return "foo", nil }),
+			[]interface{}{},
+			"foo",
+			"",
+		},
+
+		{
+			"one argument",
+			Func1(func(s string) (string, error) { return s + "!", nil }),
+			[]interface{}{"foo"},
+			"foo!",
+			"",
+		},
+
+		{
+			"two arguments",
+			Func2(func(a, b int) (int, error) { return a + b, nil }),
+			[]interface{}{1, 2},
+			3,
+			"",
+		},
+
+		{
+			"three arguments",
+			Func3(func(a string, b int, c bool) (interface{}, error) {
+				return map[string]interface{}{"a": a, "b": b, "c": c}, nil
+			}),
+			[]interface{}{"x", 1, true},
+			map[string]interface{}{"a": "x", "b": 1, "c": true},
+			"",
+		},
+
+		{
+			"convertible argument",
+			Func1(func(s string) (string, error) { return s, nil }),
+			[]interface{}{42},
+			"42",
+			"",
+		},
+
+		{
+			"convertible slice argument",
+			Func1(func(v []string) (int, error) { return len(v), nil }),
+			[]interface{}{[]interface{}{"a", "b"}},
+			2,
+			"",
+		},
+
+		{
+			"convertible to interface argument",
+			Func1(func(v interface{}) (interface{}, error) { return v, nil }),
+			[]interface{}{"foo"},
+			"foo",
+			"",
+		},
+
+		{
+			"convertible to well-known type",
+			Func1(func(d time.Duration) (string, error) { return d.String(), nil }),
+			[]interface{}{"1m30s"},
+			"1m30s",
+			"",
+		},
+
+		{
+			"null pointer argument",
+			Func1(func(d *time.Duration) (bool, error) { return d == nil, nil }),
+			[]interface{}{sdk.Null},
+			true,
+			"",
+		},
+
+		{
+			"undefined pointer argument",
+			Func1(func(d *time.Duration) (bool, error) { return d == nil, nil }),
+			[]interface{}{sdk.Undefined},
+			true,
+			"",
+		},
+
+		{
+			"null interface argument",
+			Func1(func(v interface{}) (interface{}, error) { return v, nil }),
+			[]interface{}{sdk.Null},
+			sdk.Null,
+			"",
+		},
+
+		{
+			"inconvertible argument",
+			Func1(func(v int) (int, error) { return v, nil }),
+			[]interface{}{"foo"},
+			nil,
+			"error converting argument to int",
+		},
+
+		{
+			"too few arguments",
+			Func2(func(a, b int) (int, error) { return a + b, nil }),
+			[]interface{}{1},
+			nil,
+			"expected 2 arguments, got 1",
+		},
+
+		{
+			"too many arguments",
+			Func0(func() (int, error) { return 0, nil }),
+			[]interface{}{1},
+			nil,
+			"expected 0 arguments, got 1",
+		},
+
+		{
+			"error",
+			Func1(func(string) (string, error) { return "", errors.New("failed") }),
+			[]interface{}{"foo"},
+			nil,
+			"failed",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: &rootEmbedCall{&nsCall{F: tc.Func}}}
+			results, err := p.Get([]*sdk.GetReq{
+				{Keys: []sdk.GetKey{{Key: "foo", Args: tc.Args}}},
+			})
+			if tc.ExpectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.ExpectedErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestTypedFunc_numArgs(t *testing.T) {
+	cases := []struct {
+		Func     TypedFunc
+		Expected int
+	}{
+		{Func0(func() (int, error) { return 0, nil }), 0},
+		{Func1(func(int) (int, error) { return 0, nil }), 1},
+		{Func2(func(int, int) (int, error) { return 0, nil }), 2},
+		{Func3(func(int, int, int) (int, error) { return 0, nil }), 3},
+	}
+
+	for _, tc := range cases {
+		if actual := tc.Func.NumArgs(); actual != tc.Expected {
+			t.Fatalf("%T: expected %d, got %d", tc.Func, tc.Expected, actual)
+		}
+	}
+}
+
+func TestTypedFunc_builder(t *testing.T) {
+	ns := NewNamespace().
+		Func("add", Func2(func(a, b int) (int, error) { return a + b, nil })).
+		Build()
+
+	p := &Plugin{Root: &rootBuiltNamespace{ns}}
+	results, err := p.Get([]*sdk.GetReq{
+		{Keys: []sdk.GetKey{{Key: "add", Args: []interface{}{1, 2}}}},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if actual := results[0].Value; actual != 3 {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func BenchmarkPlugin_call(b *testing.B) {
+	add := func(a, b int) (int, error) { return a + b, nil }
+	funcs := []struct {
+		Name string
+		Func interface{}
+	}{
+		{"reflect", add},
+		{"typed", Func2(add)},
+	}
+
+	args := []interface{}{1, 2}
+	for _, f := range funcs {
+		b.Run(f.Name, func(b *testing.B) {
+			p := new(Plugin)
+			for i := 0; i < b.N; i++ {
+				if _, err := p.call(f.Func, args); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@
 
 package framework
 
+import (
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
 //go:generate rm -f mock_*.go
 //go:generate mockery --inpackage --note "Generated code. DO NOT MODIFY." --name=Root --testonly
 //go:generate mockery --inpackage --note "Generated code. DO NOT MODIFY." --name=Namespace --testonly
@@ -54,10 +58,33 @@ type NamespaceCreator interface {
 	Namespace() Namespace
 }
 
+// Closer is a Namespace that holds resources, such as connections or
+// temporary files, that must be released once it is no longer used. It is
+// only used for namespaces returned by NamespaceCreator.Namespace, which
+// are closed when the policy execution they were created for is over, at
+// sdk.GetReq.ExecDeadline or earlier if the host reports it, or when the
+// plugin is closed.
+type Closer interface {
+	Namespace
+
+	// Close releases the resources of the namespace. It is called at most
+	// once for each namespace.
+	Close() error
+}
+
 // New is an interface indicating that the namespace supports object
 // construction via the handling of arbitrary object data. New is
-// only supported on root namespaces, so either created through
-// Root or NamespaceCreator.
+// supported on root namespaces, so either created through Root or
+// NamespaceCreator, and on namespaces nested within them that are
+// reached by keys without function calls, such as "resource.tags".
+//
+// Values returned from below a namespace implementing New are callable:
+// calling a function on such a value constructs a namespace from the
+// value with the nearest New above it, and calls the function on that
+// namespace. This requires a Sentinel runtime that sends
+// sdk.GetReq.ReceiverPath back with the receiver for nested namespaces,
+// as indicated by sdk.GetReq.NestedReceivers. For other runtimes, only
+// objects are callable, and only with the New of the root namespace.
 //
 // The format of the object and the kinds of namespaces returned by
 // the constructor are up to the plugin author.
@@ -72,7 +99,8 @@ type New interface {
 	//
 	// Namespaces returned by this function must implement
 	// framework.Map, or else errors will be returned on
-	// post-processing of the receiver.
+	// post-processing of the receiver, unless the namespace also
+	// implements NewValue.
 	//
 	// New should return an error if there are issues instantiating the
 	// namespace. This includes if the namespace cannot be determined
@@ -81,6 +109,22 @@ type New interface {
 	New(map[string]interface{}) (Namespace, error)
 }
 
+// NewValue is like New, for receivers that aren't objects, such as lists
+// or strings. If a namespace implements both, objects are passed to New
+// and every other receiver to NewValue; otherwise every receiver is passed
+// to NewValue. Every value returned from below a namespace implementing
+// NewValue is callable for runtimes that set sdk.GetReq.NestedReceivers,
+// and the namespaces it returns may convert to any value, such as with
+// List, rather than only Map.
+type NewValue interface {
+	Namespace
+
+	// NewValue is called to construct a namespace from the receiver data.
+	// Returning nil from this function will return undefined to the
+	// caller.
+	NewValue(interface{}) (Namespace, error)
+}
+
 // Namespace represents a namespace of attributes that can be requested
 // by key. For example in "time.pst.hour, time.pst.minute", "time.pst" would
 // be a namespace.
@@ -121,13 +165,88 @@ type Map interface {
 	Map() (map[string]interface{}, error)
 }
 
+// Path is a Namespace that needs to know where it is, such as a generic
+// backend mapping keys to REST paths. The framework calls GetPath instead
+// of Get, with the keys that led to the namespace from the root. Get is
+// still called where there is no path, such as when building a map from
+// Keys.
+type Path interface {
+	Namespace
+
+	// GetPath requests the value for a key, like Get. The path contains
+	// the keys before it, starting with the first key after the plugin
+	// name, including the arguments of keys that were function calls. For
+	// "plugin.a.b(1).c", "c" is requested with the path "a", "b" with the
+	// argument 1. The path must not be modified or retained.
+	GetPath(path []sdk.GetKey, key string) (interface{}, error)
+}
+
+// Memoize is a Namespace that declares memoization policies for its keys,
+// controlling which values are returned with the namespace as a whole
+// and which values are cached by the framework. Keys without a policy use
+// MemoDefault. NewStructNamespace and NamespaceBuilder implement Memoize
+// with the "memo" tag option and NamespaceBuilder.Memo.
+type Memoize interface {
+	Namespace
+
+	// Memoize returns the policies of the keys. It is called for every
+	// key requested, so it should return the same map each time rather
+	// than building it.
+	Memoize() map[string]Memo
+}
+
+// Has is a Namespace that reports whether it has a key. This
+// distinguishes a key that is absent, which is undefined, from a key that
+// is present with a nil value, which is null. Get is only called for keys
+// the namespace has.
+type Has interface {
+	Namespace
+
+	// Has returns whether the namespace has a value for the key. It should
+	// be cheaper than Get, since it is called before every Get.
+	Has(string) (bool, error)
+}
+
+// Keys is a Namespace that lists its keys. If the namespace doesn't
+// implement Map, List or PagedList, requesting the namespace itself
+// returns a map built with Get for each key, only when it is requested.
+type Keys interface {
+	Namespace
+
+	// Keys returns the keys of the namespace. If the namespace also
+	// implements Has, keys it doesn't have, such as keys of functions, are
+	// left out of the map.
+	Keys() []string
+}
+
 // List is a Namespace that supports returning a list of data.
+//
+// Elements can be accessed by index, such as "plugin.items[3]". If Get
+// returns nil for the index, the element is taken from List.
 type List interface {
 	Namespace
 
 	List() ([]interface{}, error)
 }
 
+// PagedList is a Namespace that returns a list one page at a time, for
+// lists that are too large to be returned by List in one go, such as
+// records paged through from a backend.
+//
+// The framework reads pages only as far as needed: indexing the
+// namespace, such as "plugin.items[3]", stops at the page containing the
+// element, while requesting the namespace itself reads every page to
+// return the whole list. Get is not called for keys that are indexes.
+type PagedList interface {
+	Namespace
+
+	// Page returns the elements of the page at cursor, and the cursor of
+	// the next page. The first page has the empty cursor, and the last
+	// page returns an empty next cursor. Pages may have any number of
+	// elements, including none.
+	Page(cursor string) (items []interface{}, next string, err error)
+}
+
 // Call is a Namespace that supports call expressions. For example, "time.now()"
 // would invoke the Func function for "now".
 type Call interface {
@@ -136,12 +255,29 @@ type Call interface {
 	// Func returns a function to call for the given string. The function
 	// must take some number of arguments and return (interface{}, error).
 	// The argument types may be Go types and the framework will handle
-	// conversion and validation automatically.
+	// conversion and validation automatically. Variadic functions are not
+	// supported.
 	//
 	// The returned function may also return only interface{}. In this case,
 	// it is assumed an error scenario is impossible. Any other number of
 	// return values will result in an error.
 	//
+	// The function may also be a TypedFunc created with Func0, Func1, Func2
+	// or Func3. Its signature is checked at compile time, and calling it
+	// doesn't require reflection.
+	//
 	// This should return nil if the key doesn't support being called.
 	Func(string) interface{}
 }
+
+// Schema is a Namespace that describes its keys, so that Validate can check
+// its functions and nested namespaces before the plugin is used.
+// BuiltNamespace implements Schema.
+type Schema interface {
+	Namespace
+
+	// Schema returns the keys of the namespace. Only Key and Kind are
+	// required; functions are requested with Call.Func and nested
+	// namespaces with Get, since those are the values the plugin uses.
+	Schema() []KeyInfo
+}
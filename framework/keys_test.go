@@ -0,0 +1,201 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestHasKeys(t *testing.T) {
+	ns := func() *nsHasKeys {
+		return &nsHasKeys{
+			Values: map[string]interface{}{"a": "foo", "b": nil},
+			Extra:  []string{"missing"},
+		}
+	}
+
+	cases := []struct {
+		Name        string
+		Value       interface{}
+		Keys        []sdk.GetKey
+		Expected    interface{}
+		ExpectedErr string
+	}{
+		{
+			"present key",
+			ns(),
+			[]sdk.GetKey{{Key: "a"}},
+			"foo",
+			"",
+		},
+
+		{
+			"present key with nil value",
+			ns(),
+			[]sdk.GetKey{{Key: "b"}},
+			sdk.Null,
+			"",
+		},
+
+		{
+			"absent key",
+			ns(),
+			[]sdk.GetKey{{Key: "missing"}},
+			sdk.Undefined,
+			"",
+		},
+
+		{
+			"has error",
+			&nsHasKeys{Err: errors.New("failed")},
+			[]sdk.GetKey{{Key: "a"}},
+			nil,
+			`error retrieving key "ns.a": failed`,
+		},
+
+		{
+			"whole namespace",
+			ns(),
+			nil,
+			map[string]interface{}{"a": "foo", "b": sdk.Null},
+			"",
+		},
+
+		{
+			"whole namespace without has",
+			&nsKeys{nsHasKeys{Values: map[string]interface{}{"a": "foo", "b": nil}}},
+			nil,
+			map[string]interface{}{"a": "foo", "b": nil},
+			"",
+		},
+
+		{
+			"nested in map",
+			&nsKeyValueMap{Value: map[string]interface{}{"sub": ns()}},
+			nil,
+			map[string]interface{}{
+				"sub": map[string]interface{}{"a": "foo", "b": sdk.Null},
+			},
+			"",
+		},
+
+		{
+			"built namespace nil value",
+			NewNamespace().Static("a", nil).Build(),
+			[]sdk.GetKey{{Key: "a"}},
+			sdk.Null,
+			"",
+		},
+
+		{
+			"built namespace function",
+			NewNamespace().Func("f", func() interface{} { return nil }).Build(),
+			[]sdk.GetKey{{Key: "f"}},
+			sdk.Undefined,
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			keys := append([]sdk.GetKey{{Key: "ns"}}, tc.Keys...)
+			p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "ns", Value: tc.Value}}}
+			results, err := p.Get([]*sdk.GetReq{{Keys: keys}})
+			if tc.ExpectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.ExpectedErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestStructNamespace_hasKeys(t *testing.T) {
+	ns, err := NewStructNamespace(&testStruct{Name: "foo"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	cases := []struct {
+		Key      string
+		Expected bool
+	}{
+		{"name", true},
+		{"note", false},   // omitted when empty
+		{"region", false}, // behind a nil embedded pointer
+		{"unknown", false},
+	}
+
+	for _, tc := range cases {
+		actual, err := ns.Has(tc.Key)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if actual != tc.Expected {
+			t.Fatalf("%s: expected %t, got %t", tc.Key, tc.Expected, actual)
+		}
+	}
+
+	keys := ns.Keys()
+	if len(keys) == 0 || keys[0] != "name" {
+		t.Fatalf("bad: %v", keys)
+	}
+}
+
+// nsHasKeys implements Has and Keys with static values. Keys also returns
+// Extra, which the namespace doesn't have. Get fails for keys the
+// namespace doesn't have, since it shouldn't be called for them.
+type nsHasKeys struct {
+	Values map[string]interface{}
+	Extra  []string
+	Err    error
+}
+
+func (v *nsHasKeys) Get(key string) (interface{}, error) {
+	if _, ok := v.Values[key]; !ok {
+		return nil, errors.New("get called for absent key")
+	}
+
+	return v.Values[key], nil
+}
+
+func (v *nsHasKeys) Has(key string) (bool, error) {
+	if v.Err != nil {
+		return false, v.Err
+	}
+
+	_, ok := v.Values[key]
+	return ok, nil
+}
+
+func (v *nsHasKeys) Keys() []string {
+	result := make([]string, 0, len(v.Values)+len(v.Extra))
+	for k := range v.Values {
+		result = append(result, k)
+	}
+
+	return append(result, v.Extra...)
+}
+
+// nsKeys implements Keys without Has.
+type nsKeys struct{ ns nsHasKeys }
+
+func (v *nsKeys) Get(key string) (interface{}, error) { return v.ns.Get(key) }
+func (v *nsKeys) Keys() []string                      { return v.ns.Keys() }
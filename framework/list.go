@@ -0,0 +1,81 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ListFromPages reads every page of a PagedList into a single slice. This
+// is a useful helper for implementing the List interface.
+func ListFromPages(l PagedList) ([]interface{}, error) {
+	var result []interface{}
+	err := walkPages(l, func(items []interface{}) bool {
+		result = append(result, items...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		result = []interface{}{}
+	}
+
+	return result, nil
+}
+
+// pagedListIndex returns the element at index i of a PagedList, reading
+// pages until the one containing it. The boolean result is false if the
+// list has no element at the index.
+func pagedListIndex(l PagedList, i int) (interface{}, bool, error) {
+	var result interface{}
+	var found bool
+	err := walkPages(l, func(items []interface{}) bool {
+		if i < len(items) {
+			result, found = items[i], true
+			return false
+		}
+
+		i -= len(items)
+		return true
+	})
+
+	return result, found, err
+}
+
+// walkPages calls f with each page of l in order, until f returns false
+// or there are no more pages.
+func walkPages(l PagedList, f func([]interface{}) bool) error {
+	var cursor string
+	for {
+		items, next, err := l.Page(cursor)
+		if err != nil {
+			return err
+		}
+
+		if !f(items) || next == "" {
+			return nil
+		}
+
+		// A list returning the same cursor would never end
+		if next == cursor {
+			return fmt.Errorf("page cursor %q repeated", next)
+		}
+
+		cursor = next
+	}
+}
+
+// parseIndex parses a selector key as a list index. The boolean result is
+// false if the key isn't a non-negative integer.
+func parseIndex(key string) (int, bool) {
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 {
+		return 0, false
+	}
+
+	return i, true
+}
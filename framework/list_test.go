@@ -0,0 +1,258 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestPagedList(t *testing.T) {
+	pages := [][]interface{}{{"a", "b"}, {}, {"c", nil}, {"d"}}
+
+	cases := []struct {
+		Name        string
+		Value       interface{}
+		Keys        []sdk.GetKey
+		Expected    interface{}
+		Pages       int
+		ExpectedErr string
+	}{
+		{
+			"whole list",
+			&nsPagedList{Pages: pages},
+			nil,
+			[]interface{}{"a", "b", "c", nil, "d"},
+			4,
+			"",
+		},
+
+		{
+			"empty list",
+			&nsPagedList{Pages: [][]interface{}{{}}},
+			nil,
+			[]interface{}{},
+			1,
+			"",
+		},
+
+		{
+			"index in first page",
+			&nsPagedList{Pages: pages},
+			[]sdk.GetKey{{Key: "1"}},
+			"b",
+			1,
+			"",
+		},
+
+		{
+			"index after empty page",
+			&nsPagedList{Pages: pages},
+			[]sdk.GetKey{{Key: "2"}},
+			"c",
+			3,
+			"",
+		},
+
+		{
+			"index of nil element",
+			&nsPagedList{Pages: pages},
+			[]sdk.GetKey{{Key: "3"}},
+			sdk.Null,
+			3,
+			"",
+		},
+
+		{
+			"index out of range",
+			&nsPagedList{Pages: pages},
+			[]sdk.GetKey{{Key: "5"}},
+			sdk.Undefined,
+			4,
+			"",
+		},
+
+		{
+			"key that isn't an index",
+			&nsPagedList{Pages: pages, Value: "value"},
+			[]sdk.GetKey{{Key: "count"}},
+			"value",
+			0,
+			"",
+		},
+
+		{
+			"page error",
+			&nsPagedList{Pages: pages, Err: errors.New("failed")},
+			[]sdk.GetKey{{Key: "0"}},
+			nil,
+			1,
+			"failed",
+		},
+
+		{
+			"repeated cursor",
+			&nsPagedList{Pages: pages, Repeat: true},
+			nil,
+			nil,
+			2,
+			`page cursor "1" repeated`,
+		},
+
+		{
+			"nested in map",
+			&nsKeyValueMap{Value: map[string]interface{}{
+				"list": &nsPagedList{Pages: pages},
+			}},
+			nil,
+			map[string]interface{}{
+				"list": []interface{}{"a", "b", "c", nil, "d"},
+			},
+			-1,
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			keys := append([]sdk.GetKey{{Key: "items"}}, tc.Keys...)
+			p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "items", Value: tc.Value}}}
+			results, err := p.Get([]*sdk.GetReq{{Keys: keys}})
+			if tc.ExpectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.ExpectedErr, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("err: %s", err)
+				}
+
+				if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+					t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+				}
+			}
+
+			if l, ok := tc.Value.(*nsPagedList); ok && l.Reads != tc.Pages {
+				t.Fatalf("expected %d pages read, got %d", tc.Pages, l.Reads)
+			}
+		})
+	}
+}
+
+func TestListIndex(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Key      string
+		Expected interface{}
+	}{
+		{
+			"slice",
+			[]interface{}{"a", "b"},
+			"1",
+			"b",
+		},
+
+		{
+			"slice out of range",
+			[]interface{}{"a", "b"},
+			"2",
+			sdk.Undefined,
+		},
+
+		{
+			"slice with negative index",
+			[]interface{}{"a", "b"},
+			"-1",
+			sdk.Undefined,
+		},
+
+		{
+			"typed slice",
+			[]int{1, 2},
+			"0",
+			1,
+		},
+
+		{
+			"array",
+			[2]string{"a", "b"},
+			"1",
+			"b",
+		},
+
+		{
+			"list namespace",
+			&nsList{Value: []interface{}{"a", "b"}},
+			"1",
+			"b",
+		},
+
+		{
+			"list namespace out of range",
+			&nsList{Value: []interface{}{"a", "b"}},
+			"2",
+			sdk.Undefined,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "items", Value: tc.Value}}}
+			results, err := p.Get([]*sdk.GetReq{
+				{Keys: []sdk.GetKey{{Key: "items"}, {Key: tc.Key}}},
+			})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+// nsPagedList implements PagedList with static pages, using the page
+// index as the cursor. Get returns Value for every key.
+type nsPagedList struct {
+	Pages  [][]interface{}
+	Value  interface{}
+	Err    error // returned instead of any page
+	Repeat bool  // repeat the cursor after the first page
+
+	Reads int
+}
+
+func (v *nsPagedList) Get(string) (interface{}, error) {
+	return v.Value, nil
+}
+
+func (v *nsPagedList) Page(cursor string) ([]interface{}, string, error) {
+	v.Reads++
+
+	var i int
+	if cursor != "" {
+		i, _ = strconv.Atoi(cursor)
+	}
+
+	if v.Err != nil {
+		return nil, "", v.Err
+	}
+
+	next := strconv.Itoa(i + 1)
+	switch {
+	case v.Repeat && i > 0:
+		next = cursor
+	case i+1 >= len(v.Pages):
+		next = ""
+	}
+
+	return v.Pages[i], next, nil
+}
@@ -3,6 +3,10 @@
 
 package framework
 
+import (
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
 // MapFromKeys creates a map[string]interface{} for a Namespace from the
 // given set of keys. This is a useful helper for implementing the Map
 // interface.
@@ -18,3 +22,36 @@ func MapFromKeys(ns Namespace, keys []string) (map[string]interface{}, error) {
 
 	return result, nil
 }
+
+// mapFromKeysNamespace creates the map for a namespace implementing Keys,
+// leaving out the keys that it doesn't have if it implements Has.
+func mapFromKeysNamespace(ns Keys) (map[string]interface{}, error) {
+	has, hasOk := ns.(Has)
+	result := make(map[string]interface{})
+	for _, k := range ns.Keys() {
+		if hasOk {
+			ok, err := has.Has(k)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				continue
+			}
+		}
+
+		v, err := ns.Get(k)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keys that are present are null rather than undefined
+		if v == nil && hasOk {
+			v = sdk.Null
+		}
+
+		result[k] = v
+	}
+
+	return result, nil
+}
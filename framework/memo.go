@@ -0,0 +1,244 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+// Memo is the memoization policy of a key, see Memoize.
+type Memo int
+
+const (
+	// MemoDefault returns the key with its namespace as the namespace
+	// decides, with Map or Keys, and retrieves it on every request.
+	MemoDefault Memo = iota
+
+	// MemoFlatten always returns the key with its namespace, even if Map
+	// or Keys leave it out.
+	MemoFlatten
+
+	// MemoLazy never returns the key with its namespace, so that it is
+	// only retrieved when it is requested by key.
+	MemoLazy
+
+	// MemoExec caches the value of the key for the policy execution, so
+	// that it is retrieved once for each execution.
+	MemoExec
+
+	// MemoGlobal caches the value of the key for the lifetime of the
+	// plugin, across policy executions.
+	//
+	// Cached values are identified by the keys leading to them from the
+	// root, not by namespace, so the value must be the same for every
+	// namespace found at that path, including namespaces created for
+	// each execution by NamespaceCreator. Values below function calls or
+	// receivers aren't cached.
+	MemoGlobal
+)
+
+func (m Memo) String() string {
+	switch m {
+	case MemoDefault:
+		return "default"
+	case MemoFlatten:
+		return "flatten"
+	case MemoLazy:
+		return "lazy"
+	case MemoExec:
+		return "exec"
+	case MemoGlobal:
+		return "global"
+	default:
+		return fmt.Sprintf("Memo(%d)", int(m))
+	}
+}
+
+// ParseMemo parses the name of a policy, as used by the "memo" struct tag
+// option: "default", "flatten", "lazy", "exec" or "global".
+func ParseMemo(s string) (Memo, error) {
+	for m := MemoDefault; m <= MemoGlobal; m++ {
+		if m.String() == s {
+			return m, nil
+		}
+	}
+
+	return MemoDefault, fmt.Errorf("unknown memoization policy %q", s)
+}
+
+// memoPolicy returns the policy of key in ns.
+func memoPolicy(ns interface{}, key string) Memo {
+	if m, ok := ns.(Memoize); ok {
+		return m.Memoize()[key]
+	}
+
+	return MemoDefault
+}
+
+// memoMap applies the MemoFlatten and MemoLazy policies of ns to its map
+// m, returning a copy if it has to be modified. Keys that are flattened
+// are retrieved with get.
+func memoMap(ns interface{}, m map[string]interface{}, get func(Namespace, string) (interface{}, error)) (map[string]interface{}, error) {
+	memo, ok := ns.(Memoize)
+	if !ok || m == nil {
+		return m, nil
+	}
+
+	copied := false
+	for k, policy := range memo.Memoize() {
+		_, present := m[k]
+		switch {
+		case policy == MemoLazy && present:
+		case policy == MemoFlatten && !present:
+		default:
+			continue
+		}
+
+		if !copied {
+			result := make(map[string]interface{}, len(m))
+			for k, v := range m {
+				result[k] = v
+			}
+
+			m, copied = result, true
+		}
+
+		if policy == MemoLazy {
+			delete(m, k)
+			continue
+		}
+
+		v, err := get(memo, k)
+		if err != nil {
+			return nil, err
+		}
+
+		if v != nil {
+			m[k] = v
+		}
+	}
+
+	return m, nil
+}
+
+// memoGet returns the value of key in ns, where path are the keys that
+// led to ns, calling GetPath for namespaces implementing Path. Values of
+// keys with the MemoExec and MemoGlobal policies are cached by their path
+// from the root, rather than by namespace, since namespaces may be created
+// for each execution or each request. Values below function calls or
+// receivers aren't identified by their path, so they aren't cached.
+func (m *Plugin) memoGet(req *sdk.GetReq, path []sdk.GetKey, ns Namespace, key string) (interface{}, error) {
+	get := func() (interface{}, error) {
+		if p, ok := ns.(Path); ok {
+			return p.GetPath(path, key)
+		}
+
+		return ns.Get(key)
+	}
+
+	policy := memoPolicy(ns, key)
+	if policy != MemoExec && policy != MemoGlobal || req.HasReceiver() {
+		return get()
+	}
+
+	for _, k := range path {
+		if k.Call() {
+			return get()
+		}
+	}
+
+	return m.memo.get(req, policy, memoKey(path, key), get)
+}
+
+// memoKey returns the cache key of key below path. The keys are separated
+// by a NUL byte, since keys may contain any other character, such as dots.
+func memoKey(path []sdk.GetKey, key string) string {
+	var b strings.Builder
+	for _, k := range path {
+		b.WriteString(k.Key)
+		b.WriteByte(0)
+	}
+
+	b.WriteString(key)
+	return b.String()
+}
+
+// memoCache is the cache of values for MemoExec and MemoGlobal, keyed by
+// memoKey. Values for an execution are cleared once it is over, and all
+// values when the plugin is closed.
+type memoCache struct {
+	sync.Mutex
+	exec   map[uint64]map[string]interface{}
+	global map[string]interface{}
+}
+
+// get returns the value cached for k with the policy, calling get to
+// retrieve it if it isn't cached yet.
+func (c *memoCache) get(req *sdk.GetReq, policy Memo, k string, get func() (interface{}, error)) (interface{}, error) {
+	c.Lock()
+	values := c.global
+	if policy == MemoExec {
+		values = c.exec[req.ExecId]
+	}
+	v, ok := values[k]
+	c.Unlock()
+	if ok {
+		return v, nil
+	}
+
+	// The value is retrieved without the lock, so it may be retrieved
+	// more than once by concurrent requests. Errors aren't cached.
+	v, err := get()
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if policy == MemoGlobal {
+		if c.global == nil {
+			c.global = make(map[string]interface{})
+		}
+
+		c.global[k] = v
+		return v, nil
+	}
+
+	if c.exec == nil {
+		c.exec = make(map[uint64]map[string]interface{})
+	}
+
+	if c.exec[req.ExecId] == nil {
+		c.exec[req.ExecId] = make(map[string]interface{})
+
+		// Clear the execution's values once it is over
+		id := req.ExecId
+		time.AfterFunc(time.Until(req.ExecDeadline), func() {
+			c.invalidate(id)
+		})
+	}
+
+	c.exec[req.ExecId][k] = v
+	return v, nil
+}
+
+// invalidate clears the cached values of an execution.
+func (c *memoCache) invalidate(id uint64) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.exec, id)
+}
+
+// reset clears all cached values.
+func (c *memoCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+	c.exec = nil
+	c.global = nil
+}
@@ -0,0 +1,406 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestParseMemo(t *testing.T) {
+	for m := MemoDefault; m <= MemoGlobal; m++ {
+		actual, err := ParseMemo(m.String())
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if actual != m {
+			t.Fatalf("expected %s, got %s", m, actual)
+		}
+	}
+
+	if _, err := ParseMemo("never"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestMemo_map(t *testing.T) {
+	values := map[string]interface{}{"a": 1, "lazy": 2}
+	ns := &nsMemo{
+		Values: values,
+		Extra:  map[string]interface{}{"flat": 3},
+		Policies: map[string]Memo{
+			"lazy": MemoLazy,
+			"flat": MemoFlatten,
+			"none": MemoFlatten,
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected interface{}
+	}{
+		{
+			"namespace",
+			ns,
+			map[string]interface{}{"a": 1, "flat": 3},
+		},
+
+		{
+			"nested namespace",
+			&nsKeyValueMap{Value: map[string]interface{}{"sub": ns}},
+			map[string]interface{}{
+				"sub": map[string]interface{}{"a": 1, "flat": 3},
+			},
+		},
+
+		{
+			"built namespace",
+			NewNamespace().
+				Static("a", 1).
+				Value("lazy", func() (interface{}, error) { panic("lazy key retrieved") }).
+				Namespace("flat", &nsKeyValueMap{Value: map[string]interface{}{"b": 2}}).
+				Memo("lazy", MemoLazy).
+				Build(),
+			map[string]interface{}{
+				"a":    1,
+				"flat": map[string]interface{}{"b": 2},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "ns", Value: tc.Value}}}
+			results, err := p.Get([]*sdk.GetReq{{Keys: []sdk.GetKey{{Key: "ns"}}}})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+
+	// The map of the namespace isn't modified
+	if !reflect.DeepEqual(values, map[string]interface{}{"a": 1, "lazy": 2}) {
+		t.Fatalf("map modified: %#v", values)
+	}
+}
+
+func TestMemo_cache(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Policy Memo
+		Execs  []uint64
+		Gets   int
+	}{
+		{"default", MemoDefault, []uint64{1, 1, 2}, 3},
+		{"exec", MemoExec, []uint64{1, 1, 2}, 2},
+		{"global", MemoGlobal, []uint64{1, 1, 2}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ns := &nsMemo{
+				Values:   map[string]interface{}{"a": "foo"},
+				Policies: map[string]Memo{"a": tc.Policy},
+			}
+
+			p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "ns", Value: ns}}}
+			for _, exec := range tc.Execs {
+				results, err := p.Get([]*sdk.GetReq{{
+					ExecId:       exec,
+					ExecDeadline: time.Now().Add(time.Minute),
+					Keys:         []sdk.GetKey{{Key: "ns"}, {Key: "a"}},
+				}})
+				if err != nil {
+					t.Fatalf("err: %s", err)
+				}
+
+				if actual := results[0].Value; actual != "foo" {
+					t.Fatalf("bad: %#v", actual)
+				}
+			}
+
+			if ns.Gets != tc.Gets {
+				t.Fatalf("expected %d gets, got %d", tc.Gets, ns.Gets)
+			}
+		})
+	}
+}
+
+func TestMemo_cacheGlobal(t *testing.T) {
+	root := &rootMemo{}
+	p := &Plugin{Root: root}
+	for exec := uint64(1); exec <= 3; exec++ {
+		results, err := p.Get([]*sdk.GetReq{{
+			ExecId:       exec,
+			ExecDeadline: time.Now().Add(time.Minute),
+			Keys:         []sdk.GetKey{{Key: "a"}},
+		}})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if actual := results[0].Value; actual != "foo" {
+			t.Fatalf("bad: %#v", actual)
+		}
+	}
+
+	// The value is retrieved once, from the namespace of the first
+	// execution, although each execution has its own namespace.
+	gets := 0
+	for _, ns := range root.created {
+		gets += ns.Gets
+	}
+
+	if len(root.created) != 3 || gets != 1 {
+		t.Fatalf("expected 1 get from 3 namespaces, got %d from %d", gets, len(root.created))
+	}
+
+	p.memo.Lock()
+	if len(p.memo.global) != 1 {
+		t.Fatalf("expected 1 cached value, got %d", len(p.memo.global))
+	}
+	p.memo.Unlock()
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p.memo.Lock()
+	defer p.memo.Unlock()
+	if len(p.memo.global) != 0 {
+		t.Fatalf("cache not cleared: %#v", p.memo.global)
+	}
+}
+
+func TestMemo_cachePath(t *testing.T) {
+	ns := &nsMemo{
+		Values:   map[string]interface{}{"a": "foo"},
+		Policies: map[string]Memo{"a": MemoGlobal},
+	}
+
+	// The same namespace is found at two paths, which are cached
+	// separately.
+	p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValueMap{Value: map[string]interface{}{
+		"x": ns,
+		"y": ns,
+	}}}}
+	for _, keys := range [][]sdk.GetKey{
+		{{Key: "x"}, {Key: "a"}},
+		{{Key: "x"}, {Key: "a"}},
+		{{Key: "y"}, {Key: "a"}},
+	} {
+		if _, err := p.Get([]*sdk.GetReq{{Keys: keys}}); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	if ns.Gets != 2 {
+		t.Fatalf("expected 2 gets, got %d", ns.Gets)
+	}
+}
+
+func TestMemo_flattenPath(t *testing.T) {
+	ns := &nsMemoPath{}
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected interface{}
+	}{
+		{
+			"namespace",
+			ns,
+			map[string]interface{}{"path": "ns"},
+		},
+
+		{
+			"nested namespace",
+			map[string]interface{}{"sub": []interface{}{ns}},
+			map[string]interface{}{
+				"sub": []interface{}{map[string]interface{}{"path": "ns.sub.0"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "ns", Value: tc.Value}}}
+			results, err := p.Get([]*sdk.GetReq{{Keys: []sdk.GetKey{{Key: "ns"}}}})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestMemo_cacheExpires(t *testing.T) {
+	ns := &nsMemo{
+		Values:   map[string]interface{}{"a": "foo"},
+		Policies: map[string]Memo{"a": MemoExec},
+	}
+
+	p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "ns", Value: ns}}}
+	req := &sdk.GetReq{
+		ExecId:       1,
+		ExecDeadline: time.Now().Add(10 * time.Millisecond),
+		Keys:         []sdk.GetKey{{Key: "ns"}, {Key: "a"}},
+	}
+	if _, err := p.Get([]*sdk.GetReq{req}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	p.memo.Lock()
+	defer p.memo.Unlock()
+	if len(p.memo.exec) != 0 {
+		t.Fatalf("cache not cleared: %#v", p.memo.exec)
+	}
+}
+
+func TestMemo_cacheExecFinished(t *testing.T) {
+	ns := &nsMemo{
+		Values:   map[string]interface{}{"a": "foo"},
+		Policies: map[string]Memo{"a": MemoExec},
+	}
+
+	p := &Plugin{Root: &rootEmbedNamespace{&nsKeyValue{Key: "ns", Value: ns}}}
+	req := &sdk.GetReq{
+		ExecId:       1,
+		ExecDeadline: time.Now().Add(time.Minute),
+		Keys:         []sdk.GetKey{{Key: "ns"}, {Key: "a"}},
+	}
+	if _, err := p.Get([]*sdk.GetReq{req}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := p.ExecFinished(1); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p.memo.Lock()
+	defer p.memo.Unlock()
+	if len(p.memo.exec) != 0 {
+		t.Fatalf("cache not cleared: %#v", p.memo.exec)
+	}
+}
+
+func TestMemo_struct(t *testing.T) {
+	type memoStruct struct {
+		Name    string
+		Records []string `sentinel:",memo=lazy"`
+	}
+
+	ns, err := NewStructNamespace(&memoStruct{Name: "foo", Records: []string{"a"}})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if actual := ns.Memoize(); !reflect.DeepEqual(actual, map[string]Memo{"records": MemoLazy}) {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	m, err := ns.Map()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if !reflect.DeepEqual(m, map[string]interface{}{"name": "foo"}) {
+		t.Fatalf("bad: %#v", m)
+	}
+
+	if v, _ := ns.Get("records"); !reflect.DeepEqual(v, []string{"a"}) {
+		t.Fatalf("bad: %#v", v)
+	}
+
+	_, err = NewStructNamespace(&struct {
+		A int `sentinel:",memo=never"`
+	}{})
+	if err == nil || !strings.Contains(err.Error(), `unknown memoization policy "never"`) {
+		t.Fatalf("expected error, got: %v", err)
+	}
+}
+
+func TestNamespaceBuilder_memoUndeclared(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+
+	NewNamespace().Memo("a", MemoLazy)
+}
+
+// nsMemo implements Map and Memoize with static values. Extra are values
+// that are left out of Map. Gets counts the calls to Get.
+type nsMemo struct {
+	Values   map[string]interface{}
+	Extra    map[string]interface{}
+	Policies map[string]Memo
+
+	Gets int
+}
+
+func (v *nsMemo) Get(key string) (interface{}, error) {
+	v.Gets++
+	if value, ok := v.Values[key]; ok {
+		return value, nil
+	}
+
+	return v.Extra[key], nil
+}
+
+func (v *nsMemo) Map() (map[string]interface{}, error) { return v.Values, nil }
+func (v *nsMemo) Memoize() map[string]Memo             { return v.Policies }
+
+// rootMemo is a NamespaceCreator that keeps track of the namespaces it
+// creates, which cache "a" with MemoGlobal.
+type rootMemo struct {
+	created []*nsMemo
+}
+
+func (r *rootMemo) Configure(map[string]interface{}) error { return nil }
+
+func (r *rootMemo) Namespace() Namespace {
+	ns := &nsMemo{
+		Values:   map[string]interface{}{"a": "foo"},
+		Policies: map[string]Memo{"a": MemoGlobal},
+	}
+	r.created = append(r.created, ns)
+	return ns
+}
+
+// nsMemoPath implements Path, Map and Memoize, flattening "path", whose
+// value is the path given to GetPath.
+type nsMemoPath struct{}
+
+func (v *nsMemoPath) Get(string) (interface{}, error) {
+	return nil, errors.New("Get called instead of GetPath")
+}
+
+func (v *nsMemoPath) GetPath(path []sdk.GetKey, key string) (interface{}, error) {
+	keys := make([]string, len(path))
+	for i, k := range path {
+		keys[i] = k.Key
+	}
+
+	return strings.Join(keys, "."), nil
+}
+
+func (v *nsMemoPath) Map() (map[string]interface{}, error) { return map[string]interface{}{}, nil }
+func (v *nsMemoPath) Memoize() map[string]Memo             { return map[string]Memo{"path": MemoFlatten} }
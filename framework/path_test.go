@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestPath(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Keys     []sdk.GetKey
+		Expected interface{}
+	}{
+		{
+			"root key",
+			[]sdk.GetKey{{Key: "path"}},
+			"",
+		},
+
+		{
+			"nested key",
+			[]sdk.GetKey{{Key: "a"}, {Key: "b"}, {Key: "path"}},
+			"a/b",
+		},
+
+		{
+			"after function call",
+			[]sdk.GetKey{{Key: "a"}, {Key: "b", Args: []interface{}{1, "x"}}, {Key: "path"}},
+			"a/b(1,x)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: &nsPath{}}
+			results, err := p.Get([]*sdk.GetReq{{Keys: tc.Keys}})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+// nsPath implements Root, Path and Call, returning itself for every key except
+// "path", which returns the path joined with slashes. Functions also
+// return the namespace.
+type nsPath struct{}
+
+func (v *nsPath) Configure(map[string]interface{}) error { return nil }
+
+func (v *nsPath) Get(string) (interface{}, error) {
+	return nil, fmt.Errorf("get called")
+}
+
+func (v *nsPath) GetPath(path []sdk.GetKey, key string) (interface{}, error) {
+	if key != "path" {
+		return v, nil
+	}
+
+	parts := make([]string, len(path))
+	for i, k := range path {
+		parts[i] = k.Key
+		if k.Call() {
+			args := make([]string, len(k.Args))
+			for j, arg := range k.Args {
+				args[j] = fmt.Sprint(arg)
+			}
+
+			parts[i] += "(" + strings.Join(args, ",") + ")"
+		}
+	}
+
+	return strings.Join(parts, "/"), nil
+}
+
+func (v *nsPath) Func(string) interface{} {
+	return func(int, string) (interface{}, error) { return v, nil }
+}
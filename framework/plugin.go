@@ -6,6 +6,7 @@ package framework
 import (
 	"errors"
 	"fmt"
+	"log"
 	"reflect"
 	"strings"
 	"sync"
@@ -15,8 +16,6 @@ import (
 	"github.com/hashicorp/sentinel-sdk/encoding"
 )
 
-var stringTyp = reflect.TypeOf("")
-
 // Plugin implements sdk.Plugin. Configure and return this structure
 // to simplify implementation of sdk.Plugin.
 type Plugin struct {
@@ -25,10 +24,39 @@ type Plugin struct {
 	// implementation for a plugin. See the docs for Root for more details.
 	Root Root
 
+	// MaxDepth is the maximum nesting depth of values returned by the
+	// plugin. Values nested deeper than this result in an error rather
+	// than exhausting the stack. If this is zero, DefaultMaxDepth is used.
+	MaxDepth int
+
+	// FieldNaming is the naming strategy for the fields of structs
+	// returned by the plugin that don't set a name in their tag. If this
+	// is nil, structs are converted with encoding.GoToValue, which uses
+	// encoding.LegacySnakeCase. Otherwise, structs are converted as
+	// namespaces from a StructNaming with this strategy.
+	FieldNaming encoding.FieldNamer
+
+	// structNaming is the StructNaming for FieldNaming, created once.
+	structNaming     *StructNaming
+	structNamingOnce sync.Once
+
 	// namespaceMap keeps track of all the Namespaces for the various
 	// executions. These are cleaned up based on the ExecDeadline.
 	namespaceMap  map[uint64]Namespace
 	namespaceLock sync.RWMutex
+
+	// memo caches values of keys with the MemoExec and MemoGlobal
+	// policies.
+	memo memoCache
+}
+
+// naming returns the StructNaming for FieldNaming.
+func (m *Plugin) naming() *StructNaming {
+	m.structNamingOnce.Do(func() {
+		m.structNaming = NewStructNaming(m.FieldNaming)
+	})
+
+	return m.structNaming
 }
 
 // plugin.Plugin impl.
@@ -43,7 +71,54 @@ func (m *Plugin) Configure(raw map[string]interface{}) error {
 	}
 
 	// Configure the object itself
-	return m.Root.Configure(raw)
+	if err := m.Root.Configure(raw); err != nil {
+		return err
+	}
+
+	// Check the structure of the plugin now, since the namespaces may
+	// only be complete once configured. Namespaces that can't be checked
+	// are only logged, since they may be valid.
+	v := validate(m.Root)
+	if err := errors.Join(v.errs...); err != nil {
+		return fmt.Errorf("invalid plugin implementation, please report a "+
+			"bug to the developer of this plugin: %s", err)
+	}
+
+	for _, err := range v.unchecked {
+		log.Printf("[WARN] framework: plugin not fully validated: %s", err)
+	}
+
+	return nil
+}
+
+// sdk.ExecFinisher impl. The namespace created for the execution is
+// closed, and values cached for it with MemoExec are cleared, without
+// waiting for the execution deadline.
+func (m *Plugin) ExecFinished(execId uint64) error {
+	m.memo.invalidate(execId)
+	return m.invalidateNamespace(execId)
+}
+
+// io.Closer impl. The namespaces of executions that aren't over yet are
+// closed, and all cached values are cleared.
+func (m *Plugin) Close() error {
+	m.memo.reset()
+
+	m.namespaceLock.Lock()
+	namespaces := m.namespaceMap
+	m.namespaceMap = nil
+	m.namespaceLock.Unlock()
+
+	var errs []error
+	for id, ns := range namespaces {
+		if c, ok := ns.(Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("error closing namespace of execution %d: %s", id, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 // plugin.Plugin impl.
@@ -53,48 +128,54 @@ func (m *Plugin) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
 		// Get the namespace
 		ns := m.namespace(req)
 
-		// If Context is supplied and the root supports New, handle it.
-		// We use the value of constructorOk later on to determine if the
-		// return value will be callable as well.
-		constructor, constructorOk := ns.(New)
-		if req.Context != nil {
-			if constructorOk {
-				var err error
-				ns, err = constructor.New(req.Context)
-				if err != nil {
-					return nil, fmt.Errorf("error instantiating namespace: %s", err)
-				}
+		// If a receiver is supplied, construct the namespace from it
+		// with the constructor at the receiver path. The constructor is
+		// used later on to determine if the return value will be callable
+		// as well, and is updated to nested constructors as keys are
+		// retrieved. Hosts without nested receivers only send receivers
+		// for the root.
+		var constructor Namespace
+		var receiverPath []string
+		if req.HasReceiver() {
+			if req.NestedReceivers {
+				receiverPath = req.ReceiverPath
+			}
 
-				if ns == nil {
-					// No namespace was returned. This is technically
-					// undefined, but we need to check to make sure there were
-					// no function calls first, or else this is an error, not
-					// undefined.
-					for i, k := range req.Keys {
-						if k.Call() {
-							return nil, fmt.Errorf(
-								"attempting to call function %q on undefined receiver",
-								strings.Join(req.GetKeys()[:i+1], "."))
-						}
-					}
+			var err error
+			constructor, err = receiverConstructor(ns, receiverPath)
+			if err != nil {
+				return nil, err
+			}
+
+			ns, err = construct(constructor, req)
+			if err != nil {
+				return nil, fmt.Errorf("error instantiating namespace: %s", err)
+			}
 
-					// If this was just a get call, we can short-circuit the
-					// result here, with undefined.
-					resp[i] = &sdk.GetResult{
-						KeyId: req.KeyId,
-						Keys:  req.GetKeys(),
-						Value: sdk.Undefined,
+			if ns == nil {
+				// No namespace was returned. This is technically
+				// undefined, but we need to check to make sure there were
+				// no function calls first, or else this is an error, not
+				// undefined.
+				for i, k := range req.Keys {
+					if k.Call() {
+						return nil, fmt.Errorf(
+							"attempting to call function %q on undefined receiver",
+							strings.Join(req.GetKeys()[:i+1], "."))
 					}
-					continue
 				}
-			} else {
-				// Invalid implementation. This should not happen and is
-				// indicative of something more than likely wrong with the
-				// runtime. Nonetheless, this is not the plugin's problem
-				// as the malformed data did not come from it.
-				return nil, errors.New(
-					"sdk.GetReq.Context present but plugin does not support framework.New")
+
+				// If this was just a get call, we can short-circuit the
+				// result here, with undefined.
+				resp[i] = &sdk.GetResult{
+					KeyId: req.KeyId,
+					Keys:  req.GetKeys(),
+					Value: sdk.Undefined,
+				}
+				continue
 			}
+		} else if isConstructor(ns) {
+			constructor = ns
 		}
 
 		// For each key, perform a get
@@ -122,7 +203,42 @@ func (m *Plugin) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
 			switch x := result.(type) {
 			// For namespaces, we get the next value in the chain
 			case Namespace:
-				v, err := x.Get(k.Key)
+				// Indexes of paged lists only read the pages needed
+				if l, ok := x.(PagedList); ok {
+					if idx, ok := parseIndex(k.Key); ok {
+						v, found, err := pagedListIndex(l, idx)
+						if err != nil {
+							return nil, fmt.Errorf(
+								"error retrieving key %q: %s",
+								strings.Join(req.GetKeys()[:i+1], "."), err)
+						}
+
+						result = v
+						if found && v == nil {
+							result = sdk.Null
+						}
+						break
+					}
+				}
+
+				// Namespaces implementing Has distinguish absent keys,
+				// which are undefined, from nil values, which are null.
+				has, hasOk := x.(Has)
+				if hasOk {
+					ok, err := has.Has(k.Key)
+					if err != nil {
+						return nil, fmt.Errorf(
+							"error retrieving key %q: %s",
+							strings.Join(req.GetKeys()[:i+1], "."), err)
+					}
+
+					if !ok {
+						result = nil
+						break
+					}
+				}
+
+				v, err := m.memoGet(req, req.Keys[:i:i], x, k.Key)
 				if err != nil {
 					return nil, fmt.Errorf(
 						"error retrieving key %q: %s",
@@ -130,6 +246,29 @@ func (m *Plugin) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
 				}
 
 				result = v
+				if v == nil && hasOk {
+					result = sdk.Null
+				}
+
+				// Lists that don't handle indexes in Get are indexed
+				// by their elements.
+				if l, ok := x.(List); ok && v == nil {
+					if idx, ok := parseIndex(k.Key); ok {
+						items, err := l.List()
+						if err != nil {
+							return nil, fmt.Errorf(
+								"error retrieving key %q: %s",
+								strings.Join(req.GetKeys()[:i+1], "."), err)
+						}
+
+						if idx < len(items) {
+							result = items[idx]
+							if result == nil {
+								result = sdk.Null
+							}
+						}
+					}
+				}
 
 			// For maps with string keys, get the value. If the value is
 			// nil, return sdk.Null to ensure that we don't mess with how
@@ -147,17 +286,28 @@ func (m *Plugin) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
 
 			// Else...
 			default:
-				// If it is a map with reflection with a string key,
-				// then access it.
+				// If it is a map with reflection, then access it. Keys
+				// are converted to the key type of the map.
 				v := reflect.ValueOf(x)
-				if v.Kind() == reflect.Map && v.Type().Key() == stringTyp {
+				if v.Kind() == reflect.Map {
 					// If the value exists within the map, set it to the value
-					if v = v.MapIndex(reflect.ValueOf(k.Key)); v.IsValid() {
+					if v = mapIndex(v, k.Key); v.IsValid() {
 						result = v.Interface()
 						break
 					}
 				}
 
+				// Lists are accessed by index
+				if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+					if idx, ok := parseIndex(k.Key); ok && idx < v.Len() {
+						result = v.Index(idx).Interface()
+						if result == nil {
+							result = sdk.Null
+						}
+						break
+					}
+				}
+
 				// Finally, its undefined
 				result = nil
 			}
@@ -165,10 +315,19 @@ func (m *Plugin) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
 			if result == nil {
 				break
 			}
+
+			// Constructors nested within the root, reached without
+			// function calls, construct the receivers of values below
+			// them. Their path is sent back with the receiver, so this
+			// requires a host that supports nested receivers.
+			if req.NestedReceivers && !req.HasReceiver() && isConstructor(result) && !callBefore(req.Keys, i) {
+				constructor = result.(Namespace)
+				receiverPath = req.GetKeys()[:i+1]
+			}
 		}
 
 		var err error
-		result, err = m.resultReflect(result)
+		result, err = m.resultReflect(result, req, req.Keys)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"error retrieving key %q: %s",
@@ -187,21 +346,37 @@ func (m *Plugin) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
 			Value: result,
 		}
 
-		// If the root supported framework.New and we have a map, flag
+		// If a constructor can construct a namespace from the result, flag
 		// the ability to call methods on the result.
-		if _, ok := result.(map[string]interface{}); ok && constructorOk {
+		if callable(constructor, result, req.NestedReceivers) {
 			resp[i].Callable = true
+			resp[i].ReceiverPath = receiverPath
 		}
 
-		// If Context was supplied, get the receiver to be returned
-		if req.Context != nil {
-			respCtxRaw, err := m.resultReflect(ns)
+		// If a receiver was supplied, get the receiver to be returned
+		if req.HasReceiver() {
+			respCtxRaw, err := m.resultReflect(ns, req, nil)
 			if err != nil {
 				return nil, fmt.Errorf(
 					"error marshaling receiver after retrieving key %q: %s",
 					strings.Join(req.GetKeys(), "."), err)
 			}
 
+			// Receivers that aren't objects are returned as they are,
+			// if the constructor and the host support them.
+			if _, ok := constructor.(NewValue); ok && req.NestedReceivers {
+				if _, ok := respCtxRaw.(map[string]interface{}); !ok {
+					if respCtxRaw == nil {
+						return nil, fmt.Errorf(
+							"error marshaling receiver after retrieving key %q: receiver is now nil",
+							strings.Join(req.GetKeys(), "."))
+					}
+
+					resp[i].Receiver = respCtxRaw
+					continue
+				}
+			}
+
 			respCtx, ok := respCtxRaw.(map[string]interface{})
 			if !ok {
 				return nil, fmt.Errorf(
@@ -227,11 +402,22 @@ func (m *Plugin) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
 	return resp, nil
 }
 
-func (m *Plugin) resultReflect(result interface{}) (interface{}, error) {
-	// If we have a Map implementation, we return the whole thing.
-	if m, ok := result.(Map); ok {
-		var err error
-		result, err = m.Map()
+// resultReflect converts the result of req, found at path, to a value that
+// can be returned across the plugin interface.
+func (m *Plugin) resultReflect(result interface{}, req *sdk.GetReq, path []sdk.GetKey) (interface{}, error) {
+	get := func(ns Namespace, key string) (interface{}, error) {
+		return m.memoGet(req, path, ns, key)
+	}
+
+	// If we have a Map implementation, we return the whole thing,
+	// applying the memoization policies of its keys.
+	if mns, ok := result.(Map); ok {
+		mv, err := mns.Map()
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = memoMap(mns, mv, get)
 		if err != nil {
 			return nil, err
 		}
@@ -245,10 +431,30 @@ func (m *Plugin) resultReflect(result interface{}) (interface{}, error) {
 		}
 	}
 
+	if l, ok := result.(PagedList); ok {
+		var err error
+		result, err = ListFromPages(l)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if k, ok := result.(Keys); ok {
+		mv, err := mapFromKeysNamespace(k)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = memoMap(k, mv, get)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// We now need to do a bit of reflection to convert any dangling
 	// namespace values into values that can be returned across the
 	// plugin interface.
-	result, err := m.reflect(result)
+	result, err := m.reflect(result, req, path)
 	if err != nil {
 		return nil, err
 	}
@@ -294,7 +500,8 @@ func (m *Plugin) namespace(req *sdk.GetReq) Namespace {
 	ns = nsFunc.Namespace()
 	m.namespaceMap[req.ExecId] = ns
 
-	// Create the expiration function
+	// Create the expiration function. There is no one to report an
+	// error closing the namespace to at this point, so it is dropped.
 	time.AfterFunc(time.Until(req.ExecDeadline), func() {
 		m.invalidateNamespace(req.ExecId)
 	})
@@ -302,19 +509,36 @@ func (m *Plugin) namespace(req *sdk.GetReq) Namespace {
 	return ns
 }
 
-func (m *Plugin) invalidateNamespace(id uint64) {
+// invalidateNamespace removes the namespace of an execution, closing it
+// if it implements Closer. It is safe to call more than once.
+func (m *Plugin) invalidateNamespace(id uint64) error {
 	m.namespaceLock.Lock()
-	defer m.namespaceLock.Unlock()
+	ns := m.namespaceMap[id]
 	delete(m.namespaceMap, id)
+	m.namespaceLock.Unlock()
+
+	// Close without the lock, so that a slow Close doesn't block the
+	// requests of other executions.
+	if c, ok := ns.(Closer); ok {
+		return c.Close()
+	}
+
+	return nil
 }
 
-// call performs the typed function call via reflection for f.
+// call performs the typed function call for f, reflecting on f unless it
+// is a TypedFunc.
 func (m *Plugin) call(f interface{}, args []interface{}) (interface{}, error) {
 	// If a function call isn't supported for this key, then it is an error
 	if f == nil {
 		return nil, fmt.Errorf("function call unsupported")
 	}
 
+	// Functions with a known signature convert their own arguments
+	if tf, ok := f.(TypedFunc); ok {
+		return tf.Call(args)
+	}
+
 	// Reflect on the function and verify it is a function
 	funcVal := reflect.ValueOf(f)
 	if funcVal.Kind() != reflect.Func {
@@ -341,18 +565,10 @@ func (m *Plugin) call(f interface{}, args []interface{}) (interface{}, error) {
 		// expect this to be rare.
 		t := funcType.In(i)
 		if !argValue.Type().AssignableTo(t) {
-			v, err := encoding.GoToValue(arg)
-			if err != nil {
-				return nil, fmt.Errorf(
-					"error converting argument to %s: %s",
-					t, err)
-			}
-
-			arg, err = encoding.ValueToGo(v, t)
+			var err error
+			arg, err = convertArgValue(arg, t)
 			if err != nil {
-				return nil, fmt.Errorf(
-					"error converting argument to %s: %s",
-					t, err)
+				return nil, err
 			}
 
 			argValue = reflect.ValueOf(arg)
@@ -6,6 +6,7 @@ package framework
 import (
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"sync"
 	"sync/atomic"
@@ -19,6 +20,8 @@ import (
 
 func TestPlugin_impl(t *testing.T) {
 	var _ sdk.Plugin = new(Plugin)
+	var _ sdk.ExecFinisher = new(Plugin)
+	var _ io.Closer = new(Plugin)
 }
 
 //-------------------------------------------------------------------
@@ -456,6 +459,206 @@ var getCases = []struct {
 		"",
 	},
 
+	{
+		"key get map value with int key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[int]string{42: "bar"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "42"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "42"},
+				KeyId: 42,
+				Value: "bar",
+			},
+		},
+		"",
+	},
+
+	{
+		"key get map value with invalid int key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[int]string{42: "bar"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "bar"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "bar"},
+				KeyId: 42,
+				Value: sdk.Undefined,
+			},
+		},
+		"",
+	},
+
+	{
+		"key get map value with float key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[float64]string{1.5: "bar"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "1.5"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "1.5"},
+				KeyId: 42,
+				Value: "bar",
+			},
+		},
+		"",
+	},
+
+	{
+		"key get map value with bool key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[bool]string{true: "bar"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "true"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "true"},
+				KeyId: 42,
+				Value: "bar",
+			},
+		},
+		"",
+	},
+
+	{
+		"key get map value with named string key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[testKey]string{"child": "bar"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "child"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "child"},
+				KeyId: 42,
+				Value: "bar",
+			},
+		},
+		"",
+	},
+
+	{
+		"key get map value with interface int key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[interface{}]interface{}{42: "bar", int64(7): "baz"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "42"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "42"},
+				KeyId: 42,
+				Value: "bar",
+			},
+		},
+		"",
+	},
+
+	{
+		"key get map value with interface int64 key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[interface{}]interface{}{42: "bar", int64(7): "baz"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "7"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "7"},
+				KeyId: 42,
+				Value: "baz",
+			},
+		},
+		"",
+	},
+
+	{
+		"key get map value with interface string key",
+		&rootEmbedNamespace{&nsKeyValue{
+			Key:   "foo",
+			Value: map[interface{}]interface{}{"42": "bar", 42: "baz"},
+		}},
+		[]*sdk.GetReq{
+			{
+				Keys: []sdk.GetKey{
+					{Key: "foo"},
+					{Key: "42"},
+				},
+				KeyId: 42,
+			},
+		},
+		[]*sdk.GetResult{
+			{
+				Keys:  []string{"foo", "42"},
+				KeyId: 42,
+				Value: "bar",
+			},
+		},
+		"",
+	},
+
 	{
 		"key get map value that is a namespace",
 		&rootEmbedNamespace{&nsKeyValue{
@@ -1474,6 +1677,83 @@ func TestPluginGetImmutable(t *testing.T) {
 	}
 }
 
+func TestPluginGet_cycle(t *testing.T) {
+	ns := &nsKeyValueMap{Value: map[string]interface{}{}}
+	ns.Value["self"] = ns
+	ns.Value["list"] = []interface{}{ns}
+
+	impt := &Plugin{Root: &rootEmbedNamespace{ns}}
+	if err := impt.Configure(map[string]interface{}{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, err := impt.Get([]*sdk.GetReq{
+		{
+			Keys:  []sdk.GetKey{{Key: "self"}},
+			KeyId: 42,
+		},
+	})
+	if err == nil {
+		t.Fatal("should error")
+	}
+
+	expected := []string{
+		`error retrieving key "self": cycle detected at value.list[0]`,
+		`error retrieving key "self": cycle detected at value.self`,
+	}
+	if err.Error() != expected[0] && err.Error() != expected[1] {
+		t.Fatalf("bad: %s", err)
+	}
+}
+
+func TestPluginGet_maxDepth(t *testing.T) {
+	value := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{
+				"c": 42,
+			},
+		},
+	}
+
+	cases := []struct {
+		MaxDepth int
+		Err      string
+	}{
+		{0, ""},
+		{4, ""},
+		{3, `error retrieving key "foo": maximum depth of 3 exceeded at value.a.b.c`},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprint(tc.MaxDepth), func(t *testing.T) {
+			impt := &Plugin{
+				Root:     &rootEmbedNamespace{&nsKeyValue{Key: "foo", Value: value}},
+				MaxDepth: tc.MaxDepth,
+			}
+			if err := impt.Configure(map[string]interface{}{}); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			_, err := impt.Get([]*sdk.GetReq{
+				{
+					Keys:  []sdk.GetKey{{Key: "foo"}},
+					KeyId: 42,
+				},
+			})
+			if tc.Err == "" && err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if tc.Err != "" && (err == nil || err.Error() != tc.Err) {
+				t.Fatalf("expected error %q, got %v", tc.Err, err)
+			}
+		})
+	}
+}
+
+// testKey is a named string type for map keys.
+type testKey string
+
 // rootEmbedNamespace embeds a Namespace for easy testing.
 type rootEmbedNamespace struct{ Namespace }
 
@@ -1736,6 +2016,139 @@ func TestPluginGet_namespaceCreatorExpire(t *testing.T) {
 	impt.namespaceLock.RUnlock()
 }
 
+// Test namespaces implementing Closer are closed when their execution is
+// over, whether it is reported or expires, or when the plugin is closed.
+func TestPluginGet_namespaceCreatorClose(t *testing.T) {
+	root := &rootClosable{}
+	impt := &Plugin{Root: root}
+	if err := impt.Configure(map[string]interface{}{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Millisecond)
+	for id := uint64(1); id <= 3; id++ {
+		req := &sdk.GetReq{
+			ExecId:       id,
+			ExecDeadline: time.Now().Add(time.Minute),
+			Keys:         []sdk.GetKey{{Key: "foo"}},
+			KeyId:        id,
+		}
+		if id == 2 {
+			req.ExecDeadline = deadline
+		}
+
+		if _, err := impt.Get([]*sdk.GetReq{req}); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	assertClosed := func(expected ...int) {
+		t.Helper()
+
+		var actual []int
+		for i, ns := range root.namespaces() {
+			if ns.closed() > 1 {
+				t.Fatalf("namespace %d closed %d times", i+1, ns.closed())
+			}
+
+			if ns.closed() == 1 {
+				actual = append(actual, i+1)
+			}
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %v closed, got %v", expected, actual)
+		}
+	}
+
+	// The execution is reported finished
+	if err := impt.ExecFinished(1); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertClosed(1)
+
+	// Reporting it again doesn't close it again
+	if err := impt.ExecFinished(1); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertClosed(1)
+
+	// The execution expires
+	time.Sleep(time.Until(deadline) + 5*time.Millisecond)
+	assertClosed(1, 2)
+
+	// The plugin is closed
+	if err := impt.Close(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertClosed(1, 2, 3)
+
+	impt.namespaceLock.RLock()
+	defer impt.namespaceLock.RUnlock()
+	if len(impt.namespaceMap) != 0 {
+		t.Fatal("should be empty")
+	}
+}
+
+func TestPluginClose_error(t *testing.T) {
+	impt := &Plugin{Root: &rootClosable{Err: errors.New("failed")}}
+	_, err := impt.Get([]*sdk.GetReq{{
+		ExecId:       1,
+		ExecDeadline: time.Now().Add(time.Minute),
+		Keys:         []sdk.GetKey{{Key: "foo"}},
+	}})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	err = impt.Close()
+	if err == nil || err.Error() != "error closing namespace of execution 1: failed" {
+		t.Fatalf("bad: %v", err)
+	}
+}
+
+// rootClosable is a NamespaceCreator that keeps track of the namespaces it
+// creates, which implement Closer and fail to close with Err.
+type rootClosable struct {
+	Err error
+
+	lock    sync.Mutex
+	created []*nsClosable
+}
+
+func (r *rootClosable) Configure(map[string]interface{}) error { return nil }
+
+func (r *rootClosable) Namespace() Namespace {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ns := &nsClosable{Err: r.Err}
+	r.created = append(r.created, ns)
+	return ns
+}
+
+func (r *rootClosable) namespaces() []*nsClosable {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.created
+}
+
+// nsClosable is a Namespace that counts the calls to Close.
+type nsClosable struct {
+	Err error
+
+	count int32
+}
+
+func (v *nsClosable) Get(string) (interface{}, error) { return "bar", nil }
+
+func (v *nsClosable) Close() error {
+	atomic.AddInt32(&v.count, 1)
+	return v.Err
+}
+
+func (v *nsClosable) closed() int { return int(atomic.LoadInt32(&v.count)) }
+
 type rootCounter struct{}
 
 func (r *rootCounter) Configure(map[string]interface{}) error { return nil }
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+// isConstructor returns true if v constructs namespaces from receivers,
+// implementing New or NewValue.
+func isConstructor(v interface{}) bool {
+	switch v.(type) {
+	case New, NewValue:
+		return true
+	default:
+		return false
+	}
+}
+
+// receiverConstructor returns the namespace at path from ns, which must
+// construct namespaces from receivers. An empty path is ns itself.
+func receiverConstructor(ns Namespace, path []string) (Namespace, error) {
+	keys := make([]sdk.GetKey, len(path))
+	for i, k := range path {
+		keys[i] = sdk.GetKey{Key: k}
+
+		var v interface{}
+		var err error
+		if p, ok := ns.(Path); ok {
+			v, err = p.GetPath(keys[:i:i], k)
+		} else {
+			v, err = ns.Get(k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving receiver constructor %q: %s",
+				strings.Join(path[:i+1], "."), err)
+		}
+
+		next, ok := v.(Namespace)
+		if !ok {
+			return nil, fmt.Errorf("receiver constructor %q is not a namespace",
+				strings.Join(path[:i+1], "."))
+		}
+
+		ns = next
+	}
+
+	if !isConstructor(ns) {
+		if len(path) == 0 {
+			// Invalid implementation. This should not happen and is
+			// indicative of something more than likely wrong with the
+			// runtime. Nonetheless, this is not the plugin's problem
+			// as the malformed data did not come from it.
+			return nil, errors.New(
+				"sdk.GetReq.Context present but plugin does not support framework.New")
+		}
+
+		return nil, fmt.Errorf(
+			"receiver constructor %q does not support framework.New",
+			strings.Join(path, "."))
+	}
+
+	return ns, nil
+}
+
+// construct returns the namespace constructed by ctor from the receiver of
+// req. Objects are passed to New if ctor implements it, and every other
+// receiver to NewValue.
+func construct(ctor Namespace, req *sdk.GetReq) (Namespace, error) {
+	if req.Context != nil {
+		if c, ok := ctor.(New); ok {
+			return c.New(req.Context)
+		}
+	}
+
+	c, ok := ctor.(NewValue)
+	if !ok {
+		return nil, fmt.Errorf(
+			"receiver is not an object, but %T does not support framework.NewValue", ctor)
+	}
+
+	if req.Context != nil {
+		return c.NewValue(req.Context)
+	}
+
+	return c.NewValue(req.Receiver)
+}
+
+// callable returns true if ctor can construct a namespace from result,
+// so that the result can be used as a receiver. Receivers that aren't
+// objects are only supported by hosts that support nested receivers.
+func callable(ctor Namespace, result interface{}, nested bool) bool {
+	if ctor == nil || result == sdk.Undefined || result == sdk.Null {
+		return false
+	}
+
+	if _, ok := ctor.(NewValue); ok && nested {
+		return true
+	}
+
+	_, ok := result.(map[string]interface{})
+	return ok
+}
+
+// callBefore returns true if any of the keys up to and including index i
+// is a function call.
+func callBefore(keys []sdk.GetKey, i int) bool {
+	for _, k := range keys[:i+1] {
+		if k.Call() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,436 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestPluginGet_receiver(t *testing.T) {
+	root := func() Root {
+		return &rootEmbedCall{&nsReceiverRoot{
+			Values: map[string]interface{}{
+				"resource": &nsKeyValueMap{Value: map[string]interface{}{
+					"tags":   &nsTags{Tags: []interface{}{"a", "b"}},
+					"object": &nsObject{},
+					"plain":  &nsKeyValueMap{Value: map[string]interface{}{"a": "b"}},
+				}},
+			},
+		}}
+	}
+
+	cases := []struct {
+		Name        string
+		Req         *sdk.GetReq
+		Resp        *sdk.GetResult
+		ExpectedErr string
+	}{
+		{
+			"nested constructor",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "resource"}, {Key: "tags"}},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:         []string{"resource", "tags"},
+				Value:        []interface{}{"a", "b"},
+				Callable:     true,
+				ReceiverPath: []string{"resource", "tags"},
+			},
+			"",
+		},
+
+		{
+			"nested object constructor",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "resource"}, {Key: "object"}},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:         []string{"resource", "object"},
+				Value:        map[string]interface{}{"value": ""},
+				Callable:     true,
+				ReceiverPath: []string{"resource", "object"},
+			},
+			"",
+		},
+
+		{
+			"nested constructor without nested receivers",
+			&sdk.GetReq{
+				Keys: []sdk.GetKey{{Key: "resource"}, {Key: "tags"}},
+			},
+			&sdk.GetResult{
+				Keys:  []string{"resource", "tags"},
+				Value: []interface{}{"a", "b"},
+			},
+			"",
+		},
+
+		{
+			"nested object constructor without nested receivers",
+			&sdk.GetReq{
+				Keys: []sdk.GetKey{{Key: "resource"}, {Key: "object"}},
+			},
+			&sdk.GetResult{
+				Keys:  []string{"resource", "object"},
+				Value: map[string]interface{}{"value": ""},
+			},
+			"",
+		},
+
+		{
+			"no constructor",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "resource"}, {Key: "plain"}},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:  []string{"resource", "plain"},
+				Value: map[string]interface{}{"a": "b"},
+			},
+			"",
+		},
+
+		{
+			"constructor after function call",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "tags", Args: []interface{}{}}},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:  []string{"tags"},
+				Value: []interface{}{"x"},
+			},
+			"",
+		},
+
+		{
+			"list receiver",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "filter", Args: []interface{}{"a"}}},
+				Receiver:        []interface{}{"a", "ab", "b"},
+				ReceiverPath:    []string{"resource", "tags"},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:         []string{"filter"},
+				Value:        []interface{}{"a", "ab"},
+				Callable:     true,
+				ReceiverPath: []string{"resource", "tags"},
+				Receiver:     []interface{}{"a", "ab", "b"},
+			},
+			"",
+		},
+
+		{
+			"list receiver updated",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "add", Args: []interface{}{"c"}}},
+				Receiver:        []interface{}{"a"},
+				ReceiverPath:    []string{"resource", "tags"},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:         []string{"add"},
+				Value:        "OK",
+				Callable:     true,
+				ReceiverPath: []string{"resource", "tags"},
+				Receiver:     []interface{}{"a", "c"},
+			},
+			"",
+		},
+
+		{
+			"object receiver for value constructor",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "filter", Args: []interface{}{"k"}}},
+				Context:         map[string]interface{}{"key": "a"},
+				ReceiverPath:    []string{"resource", "tags"},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:         []string{"filter"},
+				Value:        []interface{}{"key"},
+				Callable:     true,
+				ReceiverPath: []string{"resource", "tags"},
+				Receiver:     []interface{}{"key"},
+			},
+			"",
+		},
+
+		{
+			"object receiver",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "set", Args: []interface{}{"b"}}},
+				Context:         map[string]interface{}{"value": "a"},
+				ReceiverPath:    []string{"resource", "object"},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:    []string{"set"},
+				Value:   "OK",
+				Context: map[string]interface{}{"value": "b"},
+			},
+			"",
+		},
+
+		{
+			"list receiver for object constructor",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "set", Args: []interface{}{"b"}}},
+				Receiver:        []interface{}{"a"},
+				ReceiverPath:    []string{"resource", "object"},
+				NestedReceivers: true,
+			},
+			nil,
+			"does not support framework.NewValue",
+		},
+
+		{
+			"receiver path without constructor",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "a"}},
+				Context:         map[string]interface{}{"a": "b"},
+				ReceiverPath:    []string{"resource", "plain"},
+				NestedReceivers: true,
+			},
+			nil,
+			`receiver constructor "resource.plain" does not support framework.New`,
+		},
+
+		{
+			"receiver path not found",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "a"}},
+				Context:         map[string]interface{}{"a": "b"},
+				ReceiverPath:    []string{"unknown"},
+				NestedReceivers: true,
+			},
+			nil,
+			`receiver constructor "unknown" is not a namespace`,
+		},
+
+		{
+			"root without constructor",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "a"}},
+				Context:         map[string]interface{}{"a": "b"},
+				NestedReceivers: true,
+			},
+			nil,
+			"plugin does not support framework.New",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: root()}
+			results, err := p.Get([]*sdk.GetReq{tc.Req})
+			if tc.ExpectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.ExpectedErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(results[0], tc.Resp) {
+				t.Fatalf("expected %#v, got %#v", tc.Resp, results[0])
+			}
+		})
+	}
+}
+
+func TestPluginGet_receiverWithoutNestedReceivers(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Req         *sdk.GetReq
+		Resp        *sdk.GetResult
+		ExpectedErr string
+	}{
+		{
+			"object receiver",
+			&sdk.GetReq{
+				Keys:    []sdk.GetKey{{Key: "set", Args: []interface{}{"b"}}},
+				Context: map[string]interface{}{"value": "a"},
+			},
+			&sdk.GetResult{
+				Keys:    []string{"set"},
+				Value:   "OK",
+				Context: map[string]interface{}{"value": "b"},
+			},
+			"",
+		},
+
+		{
+			"receiver path ignored",
+			&sdk.GetReq{
+				Keys:         []sdk.GetKey{{Key: "set", Args: []interface{}{"b"}}},
+				Context:      map[string]interface{}{"value": "a"},
+				ReceiverPath: []string{"resource", "tags"},
+			},
+			&sdk.GetResult{
+				Keys:    []string{"set"},
+				Value:   "OK",
+				Context: map[string]interface{}{"value": "b"},
+			},
+			"",
+		},
+
+		{
+			"receiver no longer an object",
+			&sdk.GetReq{
+				Keys:    []sdk.GetKey{{Key: "filter", Args: []interface{}{"k"}}},
+				Context: map[string]interface{}{"key": "a"},
+			},
+			nil,
+			"receiver is no longer an object",
+		},
+
+		{
+			"receiver no longer an object with nested receivers",
+			&sdk.GetReq{
+				Keys:            []sdk.GetKey{{Key: "filter", Args: []interface{}{"k"}}},
+				Context:         map[string]interface{}{"key": "a"},
+				NestedReceivers: true,
+			},
+			&sdk.GetResult{
+				Keys:     []string{"filter"},
+				Value:    []interface{}{"key"},
+				Callable: true,
+				Receiver: []interface{}{"key"},
+			},
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{Root: &rootNewValue{}}
+			results, err := p.Get([]*sdk.GetReq{tc.Req})
+			if tc.ExpectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.ExpectedErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if !reflect.DeepEqual(results[0], tc.Resp) {
+				t.Fatalf("expected %#v, got %#v", tc.Resp, results[0])
+			}
+		})
+	}
+}
+
+// nsReceiverRoot is a root namespace with static values and a "tags"
+// function returning a value constructor.
+type nsReceiverRoot struct {
+	Values map[string]interface{}
+}
+
+func (v *nsReceiverRoot) Get(key string) (interface{}, error) {
+	return v.Values[key], nil
+}
+
+func (v *nsReceiverRoot) Func(key string) interface{} {
+	if key != "tags" {
+		return nil
+	}
+
+	return func() (interface{}, error) {
+		return &nsTags{Tags: []interface{}{"x"}}, nil
+	}
+}
+
+// nsTags is a list of tags constructed from list receivers, or from the
+// keys of object receivers.
+type nsTags struct {
+	Tags []interface{}
+}
+
+func (v *nsTags) Get(string) (interface{}, error) { return nil, nil }
+
+func (v *nsTags) List() ([]interface{}, error) { return v.Tags, nil }
+
+func (v *nsTags) NewValue(data interface{}) (Namespace, error) {
+	switch data := data.(type) {
+	case []interface{}:
+		return &nsTags{Tags: data}, nil
+
+	case map[string]interface{}:
+		var tags []interface{}
+		for k := range data {
+			tags = append(tags, k)
+		}
+
+		return &nsTags{Tags: tags}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func (v *nsTags) Func(key string) interface{} {
+	switch key {
+	case "filter":
+		return func(prefix string) (interface{}, error) {
+			var result []interface{}
+			for _, tag := range v.Tags {
+				if strings.HasPrefix(tag.(string), prefix) {
+					result = append(result, tag)
+				}
+			}
+
+			return result, nil
+		}
+
+	case "add":
+		return func(tag string) (interface{}, error) {
+			v.Tags = append(v.Tags, tag)
+			return "OK", nil
+		}
+	}
+
+	return nil
+}
+
+// nsObject is an object constructed from object receivers with New.
+type nsObject struct {
+	nsMutable
+}
+
+func (v *nsObject) New(data map[string]interface{}) (Namespace, error) {
+	value, _ := data["value"].(string)
+	return &nsObject{nsMutable{Value: value}}, nil
+}
+
+// rootNewValue is a root that constructs objects from receivers with a
+// value, and tags from any other receiver, implementing only NewValue.
+type rootNewValue struct{ nsReceiverRoot }
+
+func (r *rootNewValue) Configure(map[string]interface{}) error { return nil }
+
+func (r *rootNewValue) NewValue(data interface{}) (Namespace, error) {
+	if obj, ok := data.(map[string]interface{}); ok {
+		if _, ok := obj["value"]; ok {
+			return new(nsObject).New(obj)
+		}
+	}
+
+	return new(nsTags).NewValue(data)
+}
@@ -4,13 +4,24 @@
 package framework
 
 import (
+	"fmt"
 	"reflect"
+	"strconv"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/internal/walk"
 )
 
+// DefaultMaxDepth is the maximum nesting depth of values returned by a
+// plugin if Plugin.MaxDepth isn't set.
+const DefaultMaxDepth = 1000
+
 // various convenience types for reflect calls
 var (
 	mapTyp            = reflect.TypeOf((*Map)(nil)).Elem()
 	listTyp           = reflect.TypeOf((*List)(nil)).Elem()
+	pagedListTyp      = reflect.TypeOf((*PagedList)(nil)).Elem()
+	keysTyp           = reflect.TypeOf((*Keys)(nil)).Elem()
 	interfaceTyp      = reflect.TypeOf((*interface{})(nil)).Elem()
 	sliceInterfaceTyp = reflect.TypeOf([]interface{}{})
 )
@@ -22,8 +33,21 @@ var (
 // Currently, this means flattening them all to maps. In the future, we intend
 // to support "thunks" to allow efficiently transferring this data without
 // having to flatten it all.
-func (m *Plugin) reflect(value interface{}) (interface{}, error) {
-	v, err := m.reflectValue(reflect.ValueOf(value))
+//
+// Values that refer back to themselves, such as a namespace whose Map
+// returns a map containing the namespace, result in an error rather than
+// recursing forever, as do values nested deeper than the maximum depth.
+//
+// The value is the result of req, found at path, which is used to retrieve
+// the keys of namespaces within it that are flattened by their memoization
+// policies.
+func (m *Plugin) reflect(value interface{}, req *sdk.GetReq, path []sdk.GetKey) (interface{}, error) {
+	s := &reflectState{plugin: m, req: req, keys: path, maxDepth: m.MaxDepth}
+	if s.maxDepth == 0 {
+		s.maxDepth = DefaultMaxDepth
+	}
+
+	v, err := s.reflectValue(reflect.ValueOf(value))
 	if err != nil {
 		return nil, err
 	}
@@ -35,12 +59,54 @@ func (m *Plugin) reflect(value interface{}) (interface{}, error) {
 	return v.Interface(), nil
 }
 
-func (m *Plugin) reflectValue(v reflect.Value) (reflect.Value, error) {
+// reflectState is the state of a single call to reflect.
+type reflectState struct {
+	plugin *Plugin
+	req    *sdk.GetReq
+	keys   []sdk.GetKey // keys to the value being reflected
+
+	maxDepth int
+	depth    int
+
+	// path is the path to the value being traversed, see walk.FormatPath.
+	path []walk.Elem
+
+	// seen are the pointers, maps and slices currently being traversed,
+	// used to detect cycles.
+	seen walk.Seen
+}
+
+// memoGet retrieves a key of the namespace ns at the current path, for
+// keys that are flattened by their memoization policy. The path of
+// namespaces below non-string map keys can't be expressed as keys, so
+// their keys are retrieved with Get, without caching.
+func (s *reflectState) memoGet(ns Namespace, key string) (interface{}, error) {
+	path := make([]sdk.GetKey, len(s.keys), len(s.keys)+len(s.path))
+	copy(path, s.keys)
+	for _, elem := range s.path {
+		k, ok := elem.Selector()
+		if !ok {
+			return ns.Get(key)
+		}
+
+		path = append(path, sdk.GetKey{Key: k})
+	}
+
+	return s.plugin.memoGet(s.req, path, ns, key)
+}
+
+func (s *reflectState) reflectValue(v reflect.Value) (reflect.Value, error) {
 	// If the value isn't valid, return right away
 	if !v.IsValid() {
 		return v, nil
 	}
 
+	s.depth++
+	defer func() { s.depth-- }()
+	if s.depth > s.maxDepth {
+		return v, fmt.Errorf("maximum depth of %d exceeded at %s", s.maxDepth, walk.FormatPath(s.path))
+	}
+
 	// Unwrap the interface wrappers
 	for v.Kind() == reflect.Interface {
 		v = v.Elem()
@@ -57,6 +123,28 @@ func (m *Plugin) reflectValue(v reflect.Value) (reflect.Value, error) {
 		return ptr, nil
 	}
 
+	// Pointers are checked before calling Map or List, since those may
+	// return a value containing the receiver. Maps and slices are checked
+	// when they are copied below.
+	if v.Kind() == reflect.Ptr {
+		if err := s.visit(v); err != nil {
+			return v, err
+		}
+		defer s.unvisit(v)
+	}
+
+	// Structs are exposed as namespaces to name their fields with the
+	// naming strategy of the plugin, if it has one.
+	if s.plugin.FieldNaming != nil && isStruct(v.Type()) && v.CanInterface() &&
+		!implementsAny(v.Type(), mapTyp, listTyp, pagedListTyp, keysTyp) {
+		ns, err := s.plugin.naming().NewNamespace(v.Interface())
+		if err != nil {
+			return v, err
+		}
+
+		v = reflect.ValueOf(ns)
+	}
+
 	// If the value implements Map, then we call that and use the map
 	// value as the actual thing to look at.
 	if v.Type().Implements(mapTyp) {
@@ -65,6 +153,11 @@ func (m *Plugin) reflectValue(v reflect.Value) (reflect.Value, error) {
 			return v, err
 		}
 
+		m, err = memoMap(v.Interface(), m, s.memoGet)
+		if err != nil {
+			return v, err
+		}
+
 		v = reflect.ValueOf(m)
 	}
 
@@ -77,19 +170,42 @@ func (m *Plugin) reflectValue(v reflect.Value) (reflect.Value, error) {
 		v = reflect.ValueOf(l)
 	}
 
+	if v.Type().Implements(pagedListTyp) {
+		l, err := ListFromPages(v.Interface().(PagedList))
+		if err != nil {
+			return v, err
+		}
+
+		v = reflect.ValueOf(l)
+	}
+
+	if v.Type().Implements(keysTyp) {
+		m, err := mapFromKeysNamespace(v.Interface().(Keys))
+		if err != nil {
+			return v, err
+		}
+
+		m, err = memoMap(v.Interface(), m, s.memoGet)
+		if err != nil {
+			return v, err
+		}
+
+		v = reflect.ValueOf(m)
+	}
+
 	switch v.Kind() {
 	case reflect.Map:
-		return m.reflectMap(v)
+		return s.reflectMap(v)
 
 	case reflect.Slice:
-		return m.reflectSlice(v)
+		return s.reflectSlice(v)
 
 	default:
 		return v, nil
 	}
 }
 
-func (m *Plugin) reflectMap(mv reflect.Value) (reflect.Value, error) {
+func (s *reflectState) reflectMap(mv reflect.Value) (reflect.Value, error) {
 	// Create a new map for this. This avoids conflicts and panics on shared
 	// data, and ensures we aren't altering data in the original namespace.
 	// map[string]interface{} is always used, regardless of the actual type of
@@ -101,12 +217,19 @@ func (m *Plugin) reflectMap(mv reflect.Value) (reflect.Value, error) {
 		return mv, nil
 	}
 
+	if err := s.visit(mv); err != nil {
+		return mv, err
+	}
+	defer s.unvisit(mv)
+
 	// Otherwise make a map and proceed with copy.
 	//
 	// Preserve key type from the original map.
 	result := reflect.MakeMapWithSize(reflect.MapOf(mv.Type().Key(), interfaceTyp), mv.Len())
 	for _, k := range mv.MapKeys() {
-		v, err := m.reflectValue(mv.MapIndex(k))
+		s.path = append(s.path, walk.Key(k))
+		v, err := s.reflectValue(mv.MapIndex(k))
+		s.path = s.path[:len(s.path)-1]
 		if err != nil {
 			return mv, err
 		}
@@ -123,7 +246,7 @@ func (m *Plugin) reflectMap(mv reflect.Value) (reflect.Value, error) {
 	return result, nil
 }
 
-func (m *Plugin) reflectSlice(v reflect.Value) (reflect.Value, error) {
+func (s *reflectState) reflectSlice(v reflect.Value) (reflect.Value, error) {
 	// Create a new slice for this. This avoids conflicts and panics on
 	// shared data, and ensures that we aren't altering data in the
 	// original namespace. []interface{} is always used, regardless of
@@ -136,12 +259,19 @@ func (m *Plugin) reflectSlice(v reflect.Value) (reflect.Value, error) {
 		return v, nil
 	}
 
+	if err := s.visit(v); err != nil {
+		return v, err
+	}
+	defer s.unvisit(v)
+
 	// Otherwise make a slice and proceed with copy.
 	result := reflect.MakeSlice(sliceInterfaceTyp, v.Len(), v.Cap())
 	for i := 0; i < v.Len(); i++ {
 		elem := v.Index(i)
 
-		newElem, err := m.reflectValue(elem)
+		s.path = append(s.path, walk.Index(i))
+		newElem, err := s.reflectValue(elem)
+		s.path = s.path[:len(s.path)-1]
 		if err != nil {
 			return v, err
 		}
@@ -157,3 +287,102 @@ func (m *Plugin) reflectSlice(v reflect.Value) (reflect.Value, error) {
 
 	return result, nil
 }
+
+// mapIndex returns the value for a selector key in the map v, or the zero
+// Value if there is none. Selector keys are always strings, so the key is
+// parsed as the key type of the map. For maps with interface keys, the key
+// is tried as a string, then as an integer, float or boolean.
+func mapIndex(v reflect.Value, key string) reflect.Value {
+	keyTyp := v.Type().Key()
+	if keyTyp.Kind() != reflect.Interface {
+		k, ok := parseMapKey(key, keyTyp)
+		if !ok {
+			return reflect.Value{}
+		}
+
+		return v.MapIndex(k)
+	}
+
+	candidates := []interface{}{key}
+	if i, err := strconv.ParseInt(key, 10, 64); err == nil {
+		candidates = append(candidates, int(i), i)
+	}
+	if f, err := strconv.ParseFloat(key, 64); err == nil {
+		candidates = append(candidates, f)
+	}
+	if b, err := strconv.ParseBool(key); err == nil {
+		candidates = append(candidates, b)
+	}
+
+	for _, raw := range candidates {
+		k := reflect.ValueOf(raw)
+		if !k.Type().AssignableTo(keyTyp) {
+			continue
+		}
+
+		if result := v.MapIndex(k); result.IsValid() {
+			return result
+		}
+	}
+
+	return reflect.Value{}
+}
+
+// parseMapKey parses a selector key as a map key of type t. The boolean
+// result is false if the key can't be parsed as t.
+func parseMapKey(key string, t reflect.Type) (reflect.Value, bool) {
+	var raw interface{}
+	var err error
+	switch t.Kind() {
+	case reflect.String:
+		raw = key
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		raw, err = strconv.ParseInt(key, 10, t.Bits())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		raw, err = strconv.ParseUint(key, 10, t.Bits())
+
+	case reflect.Float32, reflect.Float64:
+		raw, err = strconv.ParseFloat(key, t.Bits())
+
+	case reflect.Bool:
+		raw, err = strconv.ParseBool(key)
+
+	default:
+		return reflect.Value{}, false
+	}
+
+	if err != nil {
+		return reflect.Value{}, false
+	}
+
+	return reflect.ValueOf(raw).Convert(t), true
+}
+
+// visit is called before traversing a pointer, map or slice, and returns
+// an error if v is already being traversed further up, meaning that the
+// value refers back to itself. Each call must be paired with a call to
+// unvisit.
+func (s *reflectState) visit(v reflect.Value) error {
+	if !s.seen.Visit(v) {
+		return fmt.Errorf("cycle detected at %s", walk.FormatPath(s.path))
+	}
+
+	return nil
+}
+
+func (s *reflectState) unvisit(v reflect.Value) {
+	s.seen.Unvisit(v)
+}
+
+// implementsAny reports whether t implements any of the interfaces.
+func implementsAny(t reflect.Type, interfaces ...reflect.Type) bool {
+	for _, i := range interfaces {
+		if t.Implements(i) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,295 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/encoding"
+	"github.com/hashicorp/sentinel-sdk/internal/tagopt"
+)
+
+// StructNamespace is a namespace exposing an ordinary Go struct, see
+// NewStructNamespace.
+type StructNamespace struct {
+	v      reflect.Value // pointer to the struct
+	info   *structInfo
+	naming *StructNaming
+}
+
+// structInfo are the keys of a struct type.
+type structInfo struct {
+	keys   []string // fields, in order
+	fields map[string]encoding.StructField
+	funcs  map[string]int // method indexes
+	memo   map[string]Memo
+}
+
+// StructNaming creates struct namespaces with keys named by a naming
+// strategy, see NewStructNaming.
+//
+// The keys of each struct type are computed once and cached in the
+// StructNaming, so a StructNaming should be created once, such as in a
+// package-level variable, and reused for every namespace.
+type StructNaming struct {
+	naming encoding.FieldNamer
+	cache  sync.Map // map[reflect.Type]*structInfo
+}
+
+// NewStructNaming returns a StructNaming that names fields without a name
+// in their tag, and methods, with naming. If naming is nil,
+// encoding.LegacySnakeCase is used, as with encoding.GoToValue.
+func NewStructNaming(naming encoding.FieldNamer) *StructNaming {
+	if naming == nil {
+		naming = encoding.LegacySnakeCase
+	}
+
+	return &StructNaming{naming: naming}
+}
+
+// defaultStructNaming is used by NewStructNamespace.
+var defaultStructNaming = NewStructNaming(nil)
+
+// NewStructNamespace returns a namespace exposing the struct v, or the
+// struct v points to, without implementing the namespace interfaces:
+//
+//   - exported fields are keys, named as with encoding.GoToValue and
+//     honoring the same "sentinel" struct tags
+//   - fields that are structs or pointers to structs are nested
+//     namespaces, unless the struct has its own conversion, such as
+//     time.Time
+//   - exported methods are functions that can be called with their key,
+//     named as fields are
+//
+// Keys are named with encoding.LegacySnakeCase. Use a StructNaming to
+// name them with another strategy.
+//
+// Methods are only called when a policy calls them, never to build the
+// keys or the map of the namespace, since methods such as Close or Reset
+// have side effects. A method without arguments is called as a function
+// without arguments, such as "ns.name()".
+//
+// Methods must return a value and optionally an error. Other methods, and
+// variadic methods, are ignored. If a field and a method have the same
+// key, the field takes precedence.
+//
+// The "memo" tag option sets the memoization policy of a field, such as
+// `sentinel:"records,memo=lazy"`, see Memoize and ParseMemo.
+//
+// The returned namespace implements Namespace, Map, Call, Has, Keys and
+// Memoize. A pointer is used directly, so changes to the struct are
+// visible to the namespace.
+func NewStructNamespace(v interface{}) (*StructNamespace, error) {
+	return defaultStructNaming.NewNamespace(v)
+}
+
+// NewNamespace returns a namespace exposing the struct v as
+// NewStructNamespace does, with keys named by the naming strategy of n.
+// Nested namespaces use the same strategy.
+func (n *StructNaming) NewNamespace(v interface{}) (*StructNamespace, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Struct:
+		// Copy to a pointer so that pointer methods are available
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		rv = ptr
+
+	case rv.Kind() == reflect.Ptr && rv.Type().Elem().Kind() == reflect.Struct:
+		if rv.IsNil() {
+			return nil, fmt.Errorf("struct namespace from nil %s", rv.Type())
+		}
+
+	default:
+		return nil, fmt.Errorf("struct namespace requires a struct or pointer to struct, got %T", v)
+	}
+
+	info, err := n.structInfo(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	return &StructNamespace{v: rv, info: info, naming: n}, nil
+}
+
+// structInfo returns the structInfo for the pointer to struct type t.
+func (n *StructNaming) structInfo(t reflect.Type) (*structInfo, error) {
+	if info, ok := n.cache.Load(t); ok {
+		return info.(*structInfo), nil
+	}
+
+	fields, err := encoding.StructFields(t.Elem(), n.naming)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &structInfo{
+		fields: make(map[string]encoding.StructField, len(fields)),
+		funcs:  make(map[string]int),
+	}
+	for _, f := range fields {
+		info.keys = append(info.keys, f.Key)
+		info.fields[f.Key] = f
+
+		if f.Memo != "" {
+			m, err := ParseMemo(f.Memo)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %s", f.Key, err)
+			}
+
+			if info.memo == nil {
+				info.memo = make(map[string]Memo)
+			}
+
+			info.memo[f.Key] = m
+		}
+	}
+
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		if validateFunc(method.Type) != nil {
+			continue
+		}
+
+		key := n.naming(method.Name)
+		if _, ok := info.fields[key]; ok {
+			continue
+		}
+
+		info.funcs[key] = i
+	}
+
+	actual, _ := n.cache.LoadOrStore(t, info)
+	return actual.(*structInfo), nil
+}
+
+// Get implements Namespace.
+func (ns *StructNamespace) Get(key string) (interface{}, error) {
+	v, ok, err := ns.get(key)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// get returns the value of key, and false if there is no value for the
+// key or it is omitted by the "omitempty" tag option.
+func (ns *StructNamespace) get(key string) (interface{}, bool, error) {
+	if f, ok := ns.info.fields[key]; ok {
+		fv, err := ns.v.Elem().FieldByIndexErr(f.Index)
+		if err != nil {
+			// The field is behind a nil embedded pointer
+			return nil, false, nil
+		}
+
+		if f.OmitEmpty && tagopt.IsEmpty(fv) {
+			return nil, false, nil
+		}
+
+		if f.AsString {
+			if str, ok := tagopt.FormatString(fv); ok {
+				return str, true, nil
+			}
+		}
+
+		return ns.value(fv)
+	}
+
+	return nil, false, nil
+}
+
+// Has implements Has.
+func (ns *StructNamespace) Has(key string) (bool, error) {
+	if f, ok := ns.info.fields[key]; ok {
+		fv, err := ns.v.Elem().FieldByIndexErr(f.Index)
+		if err != nil {
+			return false, nil
+		}
+
+		return !f.OmitEmpty || !tagopt.IsEmpty(fv), nil
+	}
+
+	return false, nil
+}
+
+// Keys implements Keys, returning the keys of fields.
+func (ns *StructNamespace) Keys() []string {
+	result := make([]string, len(ns.info.keys))
+	copy(result, ns.info.keys)
+	return result
+}
+
+// Map implements Map.
+func (ns *StructNamespace) Map() (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(ns.info.keys))
+	for _, k := range ns.info.keys {
+		if ns.info.memo[k] == MemoLazy {
+			continue
+		}
+
+		v, ok, err := ns.get(k)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", k, err)
+		}
+
+		if ok {
+			result[k] = v
+		}
+	}
+
+	return result, nil
+}
+
+// Memoize implements Memoize, returning the policies of fields set with
+// the "memo" tag option.
+func (ns *StructNamespace) Memoize() map[string]Memo {
+	return ns.info.memo
+}
+
+// Func implements Call.
+func (ns *StructNamespace) Func(key string) interface{} {
+	if idx, ok := ns.info.funcs[key]; ok {
+		return ns.v.Method(idx).Interface()
+	}
+
+	return nil
+}
+
+// value returns the value of a field. Structs and pointers to structs
+// become nested namespaces with the same naming strategy, and nil pointers
+// are null so that they are the same as the result of encoding.GoToValue.
+func (ns *StructNamespace) value(v reflect.Value) (interface{}, bool, error) {
+	if !v.CanInterface() {
+		return nil, false, nil
+	}
+
+	if !isStruct(v.Type()) {
+		return v.Interface(), true, nil
+	}
+
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return sdk.Null, true, nil
+	}
+
+	result, err := ns.naming.NewNamespace(v.Interface())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return result, true, nil
+}
+
+// isStruct reports whether t is a struct, or a pointer to a struct, that
+// is converted by its fields rather than by its own conversion.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && !encoding.HasCustomConversion(t)
+}
@@ -0,0 +1,412 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/encoding"
+)
+
+type testStructEmbedded struct {
+	Region string
+}
+
+type testStruct struct {
+	Name     string
+	Count    int    `sentinel:"total"`
+	ID       int    `sentinel:"id,string"`
+	Note     string `sentinel:",omitempty"`
+	Hidden   string `sentinel:"-"`
+	Created  time.Time
+	Child    *testStruct
+	Embedded *testStructEmbedded `sentinel:",inline"`
+	private  string
+
+	calls int
+}
+
+func (s *testStruct) Greeting() string {
+	s.calls++
+	return "hello " + s.Name
+}
+
+func (s *testStruct) Fail() (interface{}, error) {
+	return nil, errors.New("failed")
+}
+
+func (s *testStruct) Add(a, b int) (int, error) {
+	return a + b + s.Count, nil
+}
+
+func (s testStruct) Upper(v string) string {
+	return strings.ToUpper(v)
+}
+
+// Not a valid function, so ignored
+func (s *testStruct) Pair() (int, int) {
+	return 1, 2
+}
+
+func TestStructNamespace_impl(t *testing.T) {
+	var _ Map = new(StructNamespace)
+	var _ Call = new(StructNamespace)
+}
+
+func TestStructNamespace(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	newValue := func() *testStruct {
+		return &testStruct{
+			Name:     "foo",
+			Count:    2,
+			ID:       42,
+			Hidden:   "secret",
+			Created:  created,
+			Child:    &testStruct{Name: "bar"},
+			Embedded: &testStructEmbedded{Region: "us"},
+			private:  "private",
+		}
+	}
+
+	cases := []struct {
+		Name     string
+		Keys     []sdk.GetKey
+		Expected interface{}
+		Err      string
+	}{
+		{
+			"field",
+			[]sdk.GetKey{{Key: "name"}},
+			"foo",
+			"",
+		},
+
+		{
+			"tagged field",
+			[]sdk.GetKey{{Key: "total"}},
+			2,
+			"",
+		},
+
+		{
+			"string field",
+			[]sdk.GetKey{{Key: "id"}},
+			"42",
+			"",
+		},
+
+		{
+			"omitted field",
+			[]sdk.GetKey{{Key: "note"}},
+			sdk.Undefined,
+			"",
+		},
+
+		{
+			"skipped field",
+			[]sdk.GetKey{{Key: "hidden"}},
+			sdk.Undefined,
+			"",
+		},
+
+		{
+			"unexported field",
+			[]sdk.GetKey{{Key: "private"}},
+			sdk.Undefined,
+			"",
+		},
+
+		{
+			"inlined field",
+			[]sdk.GetKey{{Key: "region"}},
+			"us",
+			"",
+		},
+
+		{
+			"custom conversion",
+			[]sdk.GetKey{{Key: "created"}},
+			created,
+			"",
+		},
+
+		{
+			"nested namespace",
+			[]sdk.GetKey{{Key: "child"}, {Key: "greeting", Args: []interface{}{}}},
+			"hello bar",
+			"",
+		},
+
+		{
+			"nested nil pointer",
+			[]sdk.GetKey{{Key: "child"}, {Key: "child"}},
+			sdk.Null,
+			"",
+		},
+
+		{
+			"method without arguments",
+			[]sdk.GetKey{{Key: "greeting", Args: []interface{}{}}},
+			"hello foo",
+			"",
+		},
+
+		{
+			"method without arguments not a key",
+			[]sdk.GetKey{{Key: "greeting"}},
+			sdk.Undefined,
+			"",
+		},
+
+		{
+			"method error",
+			[]sdk.GetKey{{Key: "fail", Args: []interface{}{}}},
+			nil,
+			"failed",
+		},
+
+		{
+			"method",
+			[]sdk.GetKey{{Key: "add", Args: []interface{}{1, 2}}},
+			5,
+			"",
+		},
+
+		{
+			"value method",
+			[]sdk.GetKey{{Key: "upper", Args: []interface{}{"a"}}},
+			"A",
+			"",
+		},
+
+		{
+			"method without call",
+			[]sdk.GetKey{{Key: "add"}},
+			sdk.Undefined,
+			"",
+		},
+
+		{
+			"invalid method",
+			[]sdk.GetKey{{Key: "pair", Args: []interface{}{}}},
+			nil,
+			"function call unsupported",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			ns, err := NewStructNamespace(newValue())
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			p := &Plugin{Root: &rootStructNamespace{ns}}
+			results, err := p.Get([]*sdk.GetReq{{Keys: tc.Keys}})
+			if tc.Err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Err) {
+					t.Fatalf("expected error containing %q, got: %v", tc.Err, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+type testStructMap struct {
+	Name  string
+	Note  string `sentinel:",omitempty"`
+	Child *testStructMap
+
+	calls int
+}
+
+// Close has a side effect, so it must not be called by Map.
+func (s *testStructMap) Close() error {
+	s.calls++
+	return nil
+}
+
+func (s *testStructMap) Greeting() string {
+	s.calls++
+	return "hello " + s.Name
+}
+
+func TestStructNamespace_map(t *testing.T) {
+	v := &testStructMap{Name: "foo", Child: &testStructMap{Name: "bar"}}
+	ns, err := NewStructNamespace(v)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p := &Plugin{Root: &rootStructNamespace{ns}}
+	results, err := p.Get([]*sdk.GetReq{{Keys: []sdk.GetKey{{Key: "name"}}}})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Methods are never called to build the namespace
+	if v.calls != 0 {
+		t.Fatalf("method called %d times", v.calls)
+	}
+
+	results, err = p.Get([]*sdk.GetReq{{}})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{
+		"name": "foo",
+		"child": map[string]interface{}{
+			"name":  "bar",
+			"child": sdk.Null,
+		},
+	}
+	if actual := results[0].Value; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+
+	if v.calls != 0 {
+		t.Fatalf("method called %d times", v.calls)
+	}
+}
+
+func TestNewStructNamespace_invalid(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Value interface{}
+		Err   string
+	}{
+		{"not a struct", 42, "requires a struct or pointer to struct, got int"},
+		{"nil pointer", (*testStruct)(nil), "struct namespace from nil *framework.testStruct"},
+		{
+			"invalid tag",
+			&struct {
+				A int `sentinel:"a,bogus"`
+			}{},
+			`unknown sentinel tag option "bogus"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := NewStructNamespace(tc.Value)
+			if err == nil || !strings.Contains(err.Error(), tc.Err) {
+				t.Fatalf("expected error containing %q, got: %v", tc.Err, err)
+			}
+		})
+	}
+}
+
+type testStructNaming struct {
+	UserID string
+}
+
+func (s *testStructNaming) HomeURL() string { return "" }
+
+func TestStructNaming(t *testing.T) {
+	prefix := func(p string) encoding.FieldNamer {
+		return func(name string) string { return p + name }
+	}
+
+	cases := []struct {
+		Naming encoding.FieldNamer
+		Key    string
+		Func   string
+	}{
+		{nil, "user_i_d", "home_u_r_l"},
+		{encoding.SnakeCase, "user_id", "home_url"},
+		{prefix("a_"), "a_UserID", "a_HomeURL"},
+		{prefix("b_"), "b_UserID", "b_HomeURL"},
+	}
+
+	for _, tc := range cases {
+		ns, err := NewStructNaming(tc.Naming).NewNamespace(&testStructNaming{})
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if keys := ns.Keys(); !reflect.DeepEqual(keys, []string{tc.Key}) {
+			t.Fatalf("bad: %#v", keys)
+		}
+
+		if ns.Func(tc.Func) == nil {
+			t.Fatalf("no function %q", tc.Func)
+		}
+	}
+}
+
+type testStructNested struct {
+	UserID string
+	Value  testStructNaming
+	List   []testStructNaming
+}
+
+func TestPluginFieldNaming(t *testing.T) {
+	value := &testStructNested{
+		UserID: "a",
+		Value:  testStructNaming{UserID: "b"},
+		List:   []testStructNaming{{UserID: "c"}},
+	}
+
+	cases := []struct {
+		Name     string
+		Naming   encoding.FieldNamer
+		Expected interface{}
+	}{
+		{
+			"default",
+			nil,
+			value,
+		},
+
+		{
+			"snake case",
+			encoding.SnakeCase,
+			map[string]interface{}{
+				"user_id": "a",
+				"value":   map[string]interface{}{"user_id": "b"},
+				"list": []interface{}{
+					map[string]interface{}{"user_id": "c"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			p := &Plugin{
+				Root:        &rootEmbedNamespace{&nsKeyValue{Key: "foo", Value: value}},
+				FieldNaming: tc.Naming,
+			}
+
+			results, err := p.Get([]*sdk.GetReq{{Keys: []sdk.GetKey{{Key: "foo"}}}})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, actual)
+			}
+		})
+	}
+}
+
+// rootStructNamespace embeds a StructNamespace as a plugin root.
+type rootStructNamespace struct{ *StructNamespace }
+
+func (r *rootStructNamespace) Configure(map[string]interface{}) error { return nil }
@@ -0,0 +1,200 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Validate checks the structure of a plugin before it is used, reporting
+// every problem found rather than only the first. Plugin.Configure calls
+// it once Root is configured, rather than when the plugin is served, since
+// namespaces may only be complete once configured.
+//
+// Root must implement Namespace or NamespaceCreator. A root that is a
+// Namespace is then walked, along with its nested namespaces. The keys of
+// a namespace are taken from Schema or, failing that, from Keys:
+//
+//   - functions must implement Call, and Func must return a TypedFunc or a
+//     function that Plugin can call, taking as many arguments as declared
+//     by KeyInfo.Func, if set
+//   - nested namespaces must be returned by Get without error
+//
+// For namespaces implementing Keys, every key that isn't a function is
+// requested with Get to find nested namespaces. Values of namespaces
+// implementing Schema aren't requested, since they may be expensive or
+// change over time.
+//
+// A namespace that implements neither Schema nor Keys can't be checked,
+// which is reported with an UncheckedError. Plugin.Configure logs these as
+// warnings rather than failing. Namespaces returned by NamespaceCreator or
+// New aren't checked, since they only exist during a policy execution.
+func Validate(root Root) error {
+	v := validate(root)
+	return errors.Join(append(v.errs, v.unchecked...)...)
+}
+
+// UncheckedError is reported by Validate for a namespace that implements
+// neither Schema nor Keys, so its keys are unknown.
+type UncheckedError struct {
+	Path      string
+	Namespace Namespace
+}
+
+func (e *UncheckedError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "root"
+	}
+
+	return fmt.Sprintf("key %q: %T implements neither Schema nor Keys, so it can't be checked", path, e.Namespace)
+}
+
+// validate walks root, keeping the errors apart from the namespaces that
+// couldn't be checked.
+func validate(root Root) *validator {
+	v := &validator{visited: make(map[Namespace]bool)}
+	switch r := root.(type) {
+	case Namespace:
+		v.namespace("", r)
+
+	case NamespaceCreator:
+
+	default:
+		v.errs = append(v.errs, fmt.Errorf("root %T must implement Namespace or NamespaceCreator", root))
+	}
+
+	return v
+}
+
+// validator walks namespaces for Validate.
+type validator struct {
+	errs      []error
+	unchecked []error
+	visited   map[Namespace]bool
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("key %q: %s", path, fmt.Sprintf(format, args...)))
+}
+
+func (v *validator) namespace(path string, ns Namespace) {
+	// Namespaces may refer to each other, and only comparable namespaces
+	// can be tracked.
+	if reflect.TypeOf(ns).Comparable() {
+		if v.visited[ns] {
+			return
+		}
+
+		v.visited[ns] = true
+	}
+
+	switch ns := ns.(type) {
+	case Schema:
+		v.schema(path, ns)
+
+	case Keys:
+		v.keys(path, ns)
+
+	default:
+		v.unchecked = append(v.unchecked, &UncheckedError{Path: path, Namespace: ns})
+	}
+}
+
+func (v *validator) schema(path string, ns Schema) {
+	for _, info := range ns.Schema() {
+		keyPath := joinPath(path, info.Key)
+		switch info.Kind {
+		case KeyValue:
+
+		case KeyFunc:
+			v.function(keyPath, ns, info.Key, info.Func)
+
+		case KeyNamespace:
+			v.nested(keyPath, ns, info.Key, true)
+
+		default:
+			v.errorf(keyPath, "unknown key kind %s", info.Kind)
+		}
+	}
+}
+
+func (v *validator) keys(path string, ns Keys) {
+	c, _ := ns.(Call)
+	for _, key := range ns.Keys() {
+		keyPath := joinPath(path, key)
+		if c != nil && c.Func(key) != nil {
+			v.function(keyPath, ns, key, nil)
+			continue
+		}
+
+		v.nested(keyPath, ns, key, false)
+	}
+}
+
+// nested checks the namespace at key, if any. If required is true, the
+// key must be a namespace.
+func (v *validator) nested(path string, ns Namespace, key string, required bool) {
+	raw, err := ns.Get(key)
+	if err != nil {
+		v.errorf(path, "error retrieving namespace: %s", err)
+		return
+	}
+
+	sub, ok := raw.(Namespace)
+	if !ok {
+		if required {
+			v.errorf(path, "expected a namespace, got %T", raw)
+		}
+
+		return
+	}
+
+	v.namespace(path, sub)
+}
+
+// function checks the function at key. If declared isn't nil, the
+// function must take as many arguments.
+func (v *validator) function(path string, ns Namespace, key string, declared reflect.Type) {
+	c, ok := ns.(Call)
+	if !ok {
+		v.errorf(path, "function declared, but %T doesn't implement Call", ns)
+		return
+	}
+
+	f := c.Func(key)
+	if f == nil {
+		v.errorf(path, "function declared, but Func returned nil")
+		return
+	}
+
+	var numArgs int
+	if tf, ok := f.(TypedFunc); ok {
+		numArgs = tf.NumArgs()
+	} else {
+		t := reflect.TypeOf(f)
+		if err := validateFunc(t); err != nil {
+			v.errorf(path, "%s", err)
+			return
+		}
+
+		numArgs = t.NumIn()
+	}
+
+	if declared != nil && declared.Kind() == reflect.Func && declared.NumIn() != numArgs {
+		v.errorf(path, "function declared with %d arguments, but Func returned a function with %d",
+			declared.NumIn(), numArgs)
+	}
+}
+
+// joinPath returns the selector of key within the namespace at path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
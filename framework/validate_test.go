@@ -0,0 +1,289 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	self := &nsSchema{Keys: []KeyInfo{{Key: "self", Kind: KeyNamespace}}}
+	self.Namespaces = map[string]interface{}{"self": self}
+
+	cases := []struct {
+		Name string
+		Root Root
+		Errs []string
+	}{
+		{
+			"root with no other implementations",
+			&rootNoImpl{},
+			[]string{"must implement Namespace or NamespaceCreator"},
+		},
+
+		{
+			"root with NamespaceCreator",
+			&rootNamespaceCreator{},
+			nil,
+		},
+
+		{
+			"root without schema",
+			&rootNamespace{},
+			[]string{`key "root": *framework.rootNamespace implements neither Schema nor Keys, so it can't be checked`},
+		},
+
+		{
+			"built namespace",
+			&rootBuiltNamespace{testBuiltNamespace()},
+			[]string{`key "opaque": *framework.nsKeyValue implements neither Schema nor Keys, so it can't be checked`},
+		},
+
+		{
+			"keys",
+			&nsKeysCall{
+				Values: map[string]interface{}{
+					"value": "foo",
+					"sub": &nsKeysCall{
+						Values: map[string]interface{}{"value": 42},
+					},
+				},
+				Funcs: map[string]interface{}{
+					"f": Func1(func(string) (int, error) { return 0, nil }),
+				},
+			},
+			nil,
+		},
+
+		{
+			"invalid keys",
+			&nsKeysCall{
+				Values: map[string]interface{}{
+					"sub": &nsKeysCall{
+						Funcs: map[string]interface{}{"f": func() {}},
+					},
+					"opaque": &rootNamespace{},
+				},
+				Err:    errors.New("failed"),
+				ErrKey: "err",
+			},
+			[]string{
+				`key "sub.f": function must return a value and optionally an error, returns 0 values`,
+				`key "err": error retrieving namespace: failed`,
+				`key "opaque": *framework.rootNamespace implements neither Schema nor Keys, so it can't be checked`,
+			},
+		},
+
+		{
+			"declared arguments",
+			&nsSchema{
+				Keys: []KeyInfo{
+					{Key: "typed", Kind: KeyFunc, Func: reflect.TypeOf(func(int) {})},
+					{Key: "func", Kind: KeyFunc, Func: reflect.TypeOf(func(int, int) {})},
+					{Key: "match", Kind: KeyFunc, Func: reflect.TypeOf(func(int) {})},
+				},
+				Funcs: map[string]interface{}{
+					"typed": Func0(func() (int, error) { return 0, nil }),
+					"func":  func(int) int { return 0 },
+					"match": func(int) int { return 0 },
+				},
+			},
+			[]string{
+				`key "typed": function declared with 1 arguments, but Func returned a function with 0`,
+				`key "func": function declared with 2 arguments, but Func returned a function with 1`,
+			},
+		},
+
+		{
+			"typed function",
+			&nsSchema{
+				Keys:  []KeyInfo{{Key: "f", Kind: KeyFunc}},
+				Funcs: map[string]interface{}{"f": Func0(func() (int, error) { return 0, nil })},
+			},
+			nil,
+		},
+
+		{
+			"recursive namespace",
+			self,
+			nil,
+		},
+
+		{
+			"invalid functions",
+			&nsSchema{
+				Keys: []KeyInfo{
+					{Key: "missing", Kind: KeyFunc},
+					{Key: "value", Kind: KeyFunc},
+					{Key: "arity", Kind: KeyFunc},
+					{Key: "variadic", Kind: KeyFunc},
+				},
+				Funcs: map[string]interface{}{
+					"value":    42,
+					"arity":    func() {},
+					"variadic": func(...string) string { return "" },
+				},
+			},
+			[]string{
+				`key "missing": function declared, but Func returned nil`,
+				`key "value": expected a function, got int`,
+				`key "arity": function must return a value and optionally an error, returns 0 values`,
+				`key "variadic": variadic functions are not supported`,
+			},
+		},
+
+		{
+			"function without Call",
+			&nsKeyValueSchema{},
+			[]string{`key "f": function declared, but *framework.nsKeyValueSchema doesn't implement Call`},
+		},
+
+		{
+			"invalid nested namespaces",
+			&nsSchema{
+				Keys: []KeyInfo{
+					{Key: "value", Kind: KeyNamespace},
+					{Key: "err", Kind: KeyNamespace},
+					{Key: "sub", Kind: KeyNamespace},
+				},
+				Namespaces: map[string]interface{}{
+					"value": "foo",
+					"sub": &nsSchema{
+						Keys:  []KeyInfo{{Key: "f", Kind: KeyFunc}},
+						Funcs: map[string]interface{}{"f": "foo"},
+					},
+				},
+				Err: errors.New("failed"),
+			},
+			[]string{
+				`key "value": expected a namespace, got string`,
+				`key "err": error retrieving namespace: failed`,
+				`key "sub.f": expected a function, got string`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := Validate(tc.Root)
+			if err != nil && len(tc.Errs) != len(strings.Split(err.Error(), "\n")) {
+				t.Fatalf("expected %d errors, got: %s", len(tc.Errs), err)
+			}
+
+			if len(tc.Errs) == 0 {
+				if err != nil {
+					t.Fatalf("err: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			for _, expected := range tc.Errs {
+				if !strings.Contains(err.Error(), expected) {
+					t.Fatalf("expected error containing %q, got: %s", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_unchecked(t *testing.T) {
+	err := Validate(&rootNamespace{})
+
+	var unchecked *UncheckedError
+	if !errors.As(err, &unchecked) {
+		t.Fatalf("expected UncheckedError, got: %v", err)
+	}
+
+	// Plugin.Configure only logs namespaces that can't be checked
+	impt := &Plugin{Root: &rootNamespace{}}
+	if err := impt.Configure(map[string]interface{}{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestPluginConfigure_validate(t *testing.T) {
+	impt := &Plugin{Root: &nsSchema{
+		Keys: []KeyInfo{{Key: "f", Kind: KeyFunc}},
+	}}
+
+	err := impt.Configure(map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), `key "f"`) {
+		t.Fatalf("expected validation error, got: %v", err)
+	}
+}
+
+// nsSchema implements Root, Schema and Call with static keys. Nested namespaces
+// that aren't in Namespaces return Err.
+type nsSchema struct {
+	Keys       []KeyInfo
+	Funcs      map[string]interface{}
+	Namespaces map[string]interface{}
+	Err        error
+}
+
+func (v *nsSchema) Get(key string) (interface{}, error) {
+	if ns, ok := v.Namespaces[key]; ok {
+		return ns, nil
+	}
+
+	return nil, v.Err
+}
+
+func (v *nsSchema) Configure(map[string]interface{}) error { return nil }
+func (v *nsSchema) Func(key string) interface{}            { return v.Funcs[key] }
+func (v *nsSchema) Schema() []KeyInfo                      { return v.Keys }
+
+// nsKeyValueSchema implements Root and Schema, declaring a function without
+// implementing Call.
+type nsKeyValueSchema struct{}
+
+func (v *nsKeyValueSchema) Configure(map[string]interface{}) error { return nil }
+func (v *nsKeyValueSchema) Get(string) (interface{}, error)        { return nil, nil }
+func (v *nsKeyValueSchema) Schema() []KeyInfo {
+	return []KeyInfo{{Key: "f", Kind: KeyFunc}}
+}
+
+// nsKeysCall implements Root, Keys and Call. The key ErrKey returns Err.
+type nsKeysCall struct {
+	Values map[string]interface{}
+	Funcs  map[string]interface{}
+	Err    error
+	ErrKey string
+}
+
+func (v *nsKeysCall) Get(key string) (interface{}, error) {
+	if key == v.ErrKey {
+		return nil, v.Err
+	}
+
+	return v.Values[key], nil
+}
+
+func (v *nsKeysCall) Keys() []string {
+	var keys []string
+	for k := range v.Values {
+		keys = append(keys, k)
+	}
+	for k := range v.Funcs {
+		keys = append(keys, k)
+	}
+	if v.ErrKey != "" {
+		keys = append(keys, v.ErrKey)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *nsKeysCall) Configure(map[string]interface{}) error { return nil }
+func (v *nsKeysCall) Func(key string) interface{}            { return v.Funcs[key] }
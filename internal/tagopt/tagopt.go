@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tagopt implements the "omitempty" and "string" options of the
+// "sentinel" struct tag, shared by the encoding and framework packages so
+// that struct namespaces convert fields as GoToValue does.
+package tagopt
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// IsEmpty reports whether v is empty for the "omitempty" tag option.
+func IsEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Ptr:
+		return v.IsZero()
+	}
+
+	return false
+}
+
+// FormatString formats v for the "string" tag option. Only booleans and
+// numbers are affected, as with encoding/json, so the boolean result is
+// false for any other value, including nil pointers.
+func FormatString(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), true
+
+	default:
+		return "", false
+	}
+}
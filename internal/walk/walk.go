@@ -0,0 +1,184 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package walk contains the bookkeeping shared by the traversals of Go
+// values in the encoding and framework packages: the path to the value
+// being traversed, and the detection of values that refer back to
+// themselves.
+package walk
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Elem is an element of the path to a value: a list index, or a map key
+// as a string or a reflect.Value. This avoids allocating to box the
+// element in an interface for every value traversed.
+type Elem struct {
+	index int // -1 for map keys
+	name  string
+	key   reflect.Value
+}
+
+// Index returns the path element for the list index i.
+func Index(i int) Elem { return Elem{index: i} }
+
+// Name returns the path element for the map key name.
+func Name(name string) Elem { return Elem{index: -1, name: name} }
+
+// Key returns the path element for the map key key.
+func Key(key reflect.Value) Elem { return Elem{index: -1, key: key} }
+
+// Selector returns the element as a selector key. The boolean result is
+// false for map keys that aren't strings, which can't be expressed as
+// selector keys.
+func (e Elem) Selector() (string, bool) {
+	switch {
+	case e.index >= 0:
+		return strconv.Itoa(e.index), true
+
+	case !e.key.IsValid():
+		return e.name, true
+
+	case e.key.Kind() == reflect.String:
+		return e.key.String(), true
+
+	default:
+		return "", false
+	}
+}
+
+// FormatPath formats the path to a value for error messages in selector
+// syntax, such as value.foo[0]. The formatting is only done on error to
+// avoid the cost for every value traversed.
+func FormatPath(path []Elem) string {
+	var b strings.Builder
+	b.WriteString("value")
+	for _, elem := range path {
+		if elem.index >= 0 {
+			fmt.Fprintf(&b, "[%d]", elem.index)
+			continue
+		}
+
+		var key interface{} = elem.name
+		switch v := elem.key; {
+		case !v.IsValid():
+
+		case v.Kind() == reflect.String:
+			key = v.String()
+
+		case v.CanInterface():
+			key = v.Interface()
+
+		default:
+			key = v.String()
+		}
+
+		if name, ok := key.(string); ok {
+			if isIdentifier(name) {
+				b.WriteString("." + name)
+			} else {
+				fmt.Fprintf(&b, "[%q]", name)
+			}
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "[%v]", key)
+	}
+
+	return b.String()
+}
+
+// isIdentifier returns true if s can be used as a selector key without
+// quoting.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Seen tracks the pointers, maps and slices currently being traversed, to
+// detect cycles. The zero value is ready to use.
+type Seen struct {
+	refs map[refKey]struct{}
+}
+
+// Visit is called before traversing the contents of a pointer, map or
+// slice, and returns false if v is already being traversed further up,
+// meaning that the value refers back to itself. Each call that returns
+// true must be paired with a call to Unvisit.
+func (s *Seen) Visit(v reflect.Value) bool {
+	key, ok := newRefKey(v)
+	if !ok {
+		return true
+	}
+
+	if _, ok := s.refs[key]; ok {
+		return false
+	}
+
+	if s.refs == nil {
+		s.refs = make(map[refKey]struct{})
+	}
+	s.refs[key] = struct{}{}
+	return true
+}
+
+// Unvisit is called once the contents of v are traversed.
+func (s *Seen) Unvisit(v reflect.Value) {
+	if key, ok := newRefKey(v); ok {
+		delete(s.refs, key)
+	}
+}
+
+// Reset forgets all visited values, keeping the allocated memory for
+// reuse.
+func (s *Seen) Reset() {
+	clear(s.refs)
+}
+
+// refKey identifies a pointer, map or slice for cycle detection. The type
+// is included since a pointer to a struct and a pointer to its first field
+// share an address, and the length since slices of the same array may
+// share an address.
+type refKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// newRefKey returns the key for v. The boolean result is false if v can't
+// refer back to itself.
+func newRefKey(v reflect.Value) (refKey, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if v.IsNil() {
+			return refKey{}, false
+		}
+
+		return refKey{ptr: v.Pointer(), typ: v.Type()}, true
+
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return refKey{}, false
+		}
+
+		return refKey{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}, true
+
+	default:
+		return refKey{}, false
+	}
+}
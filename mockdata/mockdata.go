@@ -0,0 +1,504 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package mockdata contains a plugin that serves a static JSON or YAML
+// document. This allows any plugin to be mocked for policy tests without
+// writing Go.
+//
+// The plugin is configured with the path to the document:
+//
+//	{"path": "testdata/mock.yaml"}
+//
+// The format is determined by the file extension (".json", ".yaml" or
+// ".yml"), or can be set explicitly with the "format" key. The document
+// can also be given inline with the "data" key instead of "path".
+//
+// The document must be an object. Every key of the object is available
+// in the plugin, and nested objects and lists can be accessed by selector
+// or retrieved in full. For example, with the following document:
+//
+//	name: example
+//	tags:
+//	  env: prod
+//
+// both plugin.tags.env and plugin.tags are valid.
+//
+// Functions are declared with an object containing only the "__func" key,
+// with a list of stubs as its value. Each stub lists the arguments it
+// matches under "args", and the value to return under "result". A stub
+// without "args" matches any call. If no stub matches, the call returns
+// undefined. All stubs for a function that list arguments must list the
+// same number of them, and a function whose stubs don't list any can be
+// called with any number of arguments.
+//
+//	lookup:
+//	  __func:
+//	    - args: ["a"]
+//	      result: 1
+//	    - args: ["b"]
+//	      result: 2
+//	    - result: 0
+//
+// With the above, plugin.lookup("a") returns 1, and plugin.lookup("c")
+// returns 0.
+package mockdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/encoding"
+	"github.com/hashicorp/sentinel-sdk/framework"
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// FuncKey is the key identifying an object in the document as a function.
+const FuncKey = "__func"
+
+// New creates a new plugin serving a static document. This adheres to
+// the rpc.PluginFunc signature, so it can be served directly or used with
+// the testing package.
+func New() sdk.Plugin {
+	return &framework.Plugin{Root: &Root{}}
+}
+
+// Root is the framework.Root implementation for the plugin. The root
+// namespace is the top-level object of the document.
+type Root struct {
+	*object
+}
+
+// framework.Root impl.
+func (r *Root) Configure(raw map[string]interface{}) error {
+	var data interface{}
+	if v, ok := raw["data"]; ok {
+		data = v
+	} else {
+		path, ok := raw["path"].(string)
+		if !ok || path == "" {
+			return errors.New("either path or data must be configured")
+		}
+
+		format, _ := raw["format"].(string)
+		if format == "" {
+			format = strings.TrimPrefix(filepath.Ext(path), ".")
+		}
+
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		data, err = decode(contents, format)
+		if err != nil {
+			return fmt.Errorf("error decoding %q: %s", path, err)
+		}
+	}
+
+	v, err := build(data)
+	if err != nil {
+		return err
+	}
+
+	obj, ok := v.(*object)
+	if !ok {
+		return fmt.Errorf("document must be an object, got %T", data)
+	}
+
+	r.object = obj
+	return nil
+}
+
+// decode decodes the document in the given format.
+func decode(contents []byte, format string) (interface{}, error) {
+	var result interface{}
+	switch strings.ToLower(format) {
+	case "json":
+		// Decode numbers as json.Number so that integers are kept as
+		// integers, see build.
+		dec := json.NewDecoder(bytes.NewReader(contents))
+		dec.UseNumber()
+		if err := dec.Decode(&result); err != nil {
+			return nil, err
+		}
+
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(contents, &result); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	return result, nil
+}
+
+// build converts the decoded document into the values served by the
+// plugin. Objects become *object, lists become *list, and function
+// declarations become *function.
+func build(raw interface{}) (interface{}, error) {
+	switch x := raw.(type) {
+	case nil:
+		return sdk.Null, nil
+
+	case json.Number:
+		if v, err := x.Int64(); err == nil {
+			return v, nil
+		}
+
+		return x.Float64()
+
+	case int:
+		// YAML decodes integers as int, use int64 as elsewhere in the SDK.
+		return int64(x), nil
+
+	case []interface{}:
+		result := make([]interface{}, len(x))
+		for i, elem := range x {
+			v, err := build(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %s", i, err)
+			}
+
+			result[i] = v
+		}
+
+		return &list{elems: result}, nil
+
+	case map[string]interface{}:
+		if stubs, ok := x[FuncKey]; ok && len(x) == 1 {
+			return buildFunction(stubs)
+		}
+
+		result := make(map[string]interface{}, len(x))
+		for k, elem := range x {
+			v, err := build(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", k, err)
+			}
+
+			result[k] = v
+		}
+
+		return &object{values: result}, nil
+
+	case map[interface{}]interface{}:
+		// YAML documents may contain non-string keys, which we convert
+		// to strings, as namespace keys are always strings.
+		m := make(map[string]interface{}, len(x))
+		for k, v := range x {
+			m[fmt.Sprint(k)] = v
+		}
+
+		return build(m)
+
+	default:
+		return x, nil
+	}
+}
+
+// object is a namespace for an object within the document.
+type object struct {
+	values map[string]interface{}
+}
+
+// framework.Namespace impl.
+func (o *object) Get(key string) (interface{}, error) {
+	v, ok := o.values[key]
+	if !ok {
+		return nil, nil
+	}
+
+	// Functions have no value, they can only be called.
+	if _, ok := v.(*function); ok {
+		return nil, nil
+	}
+
+	return v, nil
+}
+
+// framework.Map impl.
+func (o *object) Map() (map[string]interface{}, error) {
+	return plain(o).(map[string]interface{}), nil
+}
+
+// framework.Call impl.
+func (o *object) Func(key string) interface{} {
+	if f, ok := o.values[key].(*function); ok {
+		return f
+	}
+
+	return nil
+}
+
+// list is a namespace for a list within the document. Elements can be
+// retrieved by index.
+type list struct {
+	elems []interface{}
+}
+
+// framework.Namespace impl.
+func (l *list) Get(key string) (interface{}, error) {
+	idx, err := strconv.Atoi(key)
+	if err != nil || idx < 0 || idx >= len(l.elems) {
+		return nil, nil
+	}
+
+	if _, ok := l.elems[idx].(*function); ok {
+		return nil, nil
+	}
+
+	return l.elems[idx], nil
+}
+
+// framework.List impl.
+func (l *list) List() ([]interface{}, error) {
+	return plain(l).([]interface{}), nil
+}
+
+// plain converts a built value back into plain data, omitting functions.
+func plain(v interface{}) interface{} {
+	switch x := v.(type) {
+	case *object:
+		result := make(map[string]interface{}, len(x.values))
+		for k, elem := range x.values {
+			if _, ok := elem.(*function); ok {
+				continue
+			}
+
+			result[k] = plain(elem)
+		}
+
+		return result
+
+	case *list:
+		result := make([]interface{}, 0, len(x.elems))
+		for _, elem := range x.elems {
+			if _, ok := elem.(*function); ok {
+				continue
+			}
+
+			result = append(result, plain(elem))
+		}
+
+		return result
+
+	default:
+		return v
+	}
+}
+
+// function is a function declared in the document. It implements
+// framework.TypedFunc, so that it can take any number of arguments if no
+// stub lists them.
+type function struct {
+	stubs []*stub
+	arity int // -1 if no stub lists args
+}
+
+// stub is a single stubbed call of a function. args is nil if the stub
+// matches any arguments.
+type stub struct {
+	args   []*proto.Value
+	result interface{}
+}
+
+// buildFunction builds a function from its list of stubs.
+func buildFunction(raw interface{}) (*function, error) {
+	stubsRaw, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of stubs", FuncKey)
+	}
+
+	f := &function{arity: -1}
+	for i, rawStub := range stubsRaw {
+		m, ok := rawStub.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: stub %d must be an object", FuncKey, i)
+		}
+
+		result, err := build(m["result"])
+		if err != nil {
+			return nil, fmt.Errorf("%s: stub %d: %s", FuncKey, i, err)
+		}
+
+		s := &stub{result: result}
+		if rawArgs, ok := m["args"]; ok {
+			args, ok := rawArgs.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: stub %d: args must be a list", FuncKey, i)
+			}
+
+			if f.arity >= 0 && f.arity != len(args) {
+				return nil, fmt.Errorf(
+					"%s: stub %d: expected %d args, got %d", FuncKey, i, f.arity, len(args))
+			}
+			f.arity = len(args)
+
+			s.args = make([]*proto.Value, len(args))
+			for j, arg := range args {
+				built, err := build(arg)
+				if err != nil {
+					return nil, fmt.Errorf("%s: stub %d: arg %d: %s", FuncKey, i, j, err)
+				}
+
+				s.args[j], err = encoding.GoToValue(plain(built))
+				if err != nil {
+					return nil, fmt.Errorf("%s: stub %d: arg %d: %s", FuncKey, i, j, err)
+				}
+			}
+		}
+
+		f.stubs = append(f.stubs, s)
+	}
+
+	return f, nil
+}
+
+// framework.TypedFunc impl. The number of arguments is -1 if the function
+// takes any number of them.
+func (f *function) NumArgs() int {
+	return f.arity
+}
+
+// framework.TypedFunc impl. The result of the first matching stub is
+// returned.
+func (f *function) Call(in []interface{}) (interface{}, error) {
+	if f.arity >= 0 && len(in) != f.arity {
+		return nil, fmt.Errorf("expected %d arguments, got %d", f.arity, len(in))
+	}
+
+	args := make([]*proto.Value, len(in))
+	for i, v := range in {
+		var err error
+		args[i], err = encoding.GoToValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("arg %d: %s", i, err)
+		}
+	}
+
+STUBS:
+	for _, s := range f.stubs {
+		if s.args != nil {
+			for i, arg := range s.args {
+				if !valueEqual(arg, args[i]) {
+					continue STUBS
+				}
+			}
+		}
+
+		return s.result, nil
+	}
+
+	return nil, nil
+}
+
+// valueEqual compares two values. Maps are compared regardless of the
+// order of their elements, and numbers are compared by their numeric value,
+// since documents do not always distinguish integers and floats.
+func valueEqual(a, b *proto.Value) bool {
+	switch {
+	case isNumber(a) && isNumber(b):
+		x, y := number(a), number(b)
+		return x != nil && y != nil && x.Cmp(y) == 0
+
+	case a.Type != b.Type:
+		return false
+	}
+
+	switch a.Type {
+	case proto.Value_LIST:
+		as, bs := a.GetValueList().GetElems(), b.GetValueList().GetElems()
+		if len(as) != len(bs) {
+			return false
+		}
+
+		for i := range as {
+			if !valueEqual(as[i], bs[i]) {
+				return false
+			}
+		}
+
+		return true
+
+	case proto.Value_MAP:
+		as, bs := a.GetValueMap().GetElems(), b.GetValueMap().GetElems()
+		if len(as) != len(bs) {
+			return false
+		}
+
+	KEYS:
+		for _, akv := range as {
+			for _, bkv := range bs {
+				if valueEqual(akv.Key, bkv.Key) {
+					if !valueEqual(akv.Value, bkv.Value) {
+						return false
+					}
+
+					continue KEYS
+				}
+			}
+
+			return false
+		}
+
+		return true
+
+	case proto.Value_BOOL:
+		return a.GetValueBool() == b.GetValueBool()
+
+	case proto.Value_STRING:
+		return a.GetValueString() == b.GetValueString()
+
+	default:
+		// Null and undefined
+		return true
+	}
+}
+
+func isNumber(v *proto.Value) bool {
+	switch v.Type {
+	case proto.Value_INT, proto.Value_FLOAT, proto.Value_DECIMAL:
+		return true
+
+	default:
+		return false
+	}
+}
+
+// number returns the numeric value of a number, or nil if it is NaN or an
+// invalid decimal, which are not equal to any number.
+func number(v *proto.Value) *big.Float {
+	switch v.Type {
+	case proto.Value_INT:
+		return new(big.Float).SetInt64(v.GetValueInt())
+
+	case proto.Value_FLOAT:
+		if math.IsNaN(v.GetValueFloat()) {
+			return nil
+		}
+
+		return big.NewFloat(v.GetValueFloat())
+
+	default:
+		s := v.GetValueDecimal()
+		f, ok := new(big.Float).SetPrec(uint(len(s))*4 + 64).SetString(s)
+		if !ok {
+			return nil
+		}
+
+		return f
+	}
+}
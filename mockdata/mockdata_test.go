@@ -0,0 +1,216 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package mockdata
+
+import (
+	"reflect"
+	"testing"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/framework"
+)
+
+func TestRoot_impl(t *testing.T) {
+	var _ framework.Root = new(Root)
+	var _ framework.Namespace = new(Root)
+	var _ framework.Map = new(Root)
+	var _ framework.Call = new(Root)
+	var _ framework.TypedFunc = new(function)
+}
+
+func TestPlugin(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Keys     []sdk.GetKey
+		Expected interface{}
+		Err      bool
+	}{
+		{
+			"scalar",
+			[]sdk.GetKey{{Key: "name"}},
+			"example",
+			false,
+		},
+
+		{
+			"integer",
+			[]sdk.GetKey{{Key: "count"}},
+			int64(3),
+			false,
+		},
+
+		{
+			"nested key",
+			[]sdk.GetKey{{Key: "tags"}, {Key: "env"}},
+			"prod",
+			false,
+		},
+
+		{
+			"map",
+			[]sdk.GetKey{{Key: "tags"}},
+			map[string]interface{}{"env": "prod", "owner": "ops"},
+			false,
+		},
+
+		{
+			"list",
+			[]sdk.GetKey{{Key: "items"}},
+			[]interface{}{
+				map[string]interface{}{"id": int64(1)},
+				map[string]interface{}{"id": int64(2)},
+			},
+			false,
+		},
+
+		{
+			"list index",
+			[]sdk.GetKey{{Key: "items"}, {Key: "1"}, {Key: "id"}},
+			int64(2),
+			false,
+		},
+
+		{
+			"missing",
+			[]sdk.GetKey{{Key: "nope"}},
+			sdk.Undefined,
+			false,
+		},
+
+		{
+			"function value",
+			[]sdk.GetKey{{Key: "lookup"}},
+			sdk.Undefined,
+			false,
+		},
+
+		{
+			"function match",
+			[]sdk.GetKey{{Key: "lookup", Args: []interface{}{"a", int64(1)}}},
+			"first",
+			false,
+		},
+
+		{
+			"function match nested",
+			[]sdk.GetKey{
+				{Key: "lookup", Args: []interface{}{"b", 2.0}},
+				{Key: "nested"},
+			},
+			true,
+			false,
+		},
+
+		{
+			"function fallback",
+			[]sdk.GetKey{{Key: "lookup", Args: []interface{}{"c", int64(3)}}},
+			"fallback",
+			false,
+		},
+
+		{
+			"function without args",
+			[]sdk.GetKey{{Key: "any", Args: []interface{}{}}},
+			"any",
+			false,
+		},
+
+		{
+			"function without args called with args",
+			[]sdk.GetKey{{Key: "any", Args: []interface{}{"a", int64(1)}}},
+			"any",
+			false,
+		},
+
+		{
+			"function wrong arity",
+			[]sdk.GetKey{{Key: "lookup", Args: []interface{}{"a"}}},
+			nil,
+			true,
+		},
+	}
+
+	for _, path := range []string{"testdata/mock.json", "testdata/mock.yaml"} {
+		p := New()
+		if err := p.Configure(map[string]interface{}{"path": path}); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		for _, tc := range cases {
+			t.Run(path+"/"+tc.Name, func(t *testing.T) {
+				results, err := p.Get([]*sdk.GetReq{{KeyId: 1, Keys: tc.Keys}})
+				if (err != nil) != tc.Err {
+					t.Fatalf("err: %s", err)
+				}
+				if tc.Err {
+					return
+				}
+
+				if actual := results[0].Value; !reflect.DeepEqual(actual, tc.Expected) {
+					t.Fatalf("bad: %#v", actual)
+				}
+			})
+		}
+	}
+}
+
+func TestRootConfigure(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config map[string]interface{}
+		Err    bool
+	}{
+		{
+			"inline data",
+			map[string]interface{}{"data": map[string]interface{}{"foo": "bar"}},
+			false,
+		},
+
+		{
+			"explicit format",
+			map[string]interface{}{"path": "testdata/mock.json", "format": "yaml"},
+			false,
+		},
+
+		{
+			"no path",
+			map[string]interface{}{},
+			true,
+		},
+
+		{
+			"unknown format",
+			map[string]interface{}{"path": "testdata/mock.json", "format": "xml"},
+			true,
+		},
+
+		{
+			"not an object",
+			map[string]interface{}{"data": []interface{}{"foo"}},
+			true,
+		},
+
+		{
+			"inconsistent arity",
+			map[string]interface{}{"data": map[string]interface{}{
+				"f": map[string]interface{}{
+					FuncKey: []interface{}{
+						map[string]interface{}{"args": []interface{}{1}},
+						map[string]interface{}{"args": []interface{}{1, 2}},
+					},
+				},
+			}},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := new(Root).Configure(tc.Config)
+			if (err != nil) != tc.Err {
+				t.Fatalf("err: %s", err)
+			}
+		})
+	}
+}
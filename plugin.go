@@ -31,6 +31,12 @@ var (
 // package, allowing for the null type to be marshalled as nil
 func (*null) MarshalJSON() ([]byte, error) { return json.Marshal(nil) }
 
+// MarshalJSON provides custom marshalling logic to the encoding/json
+// package. JSON has no undefined value, so undefined is marshalled as nil
+// like null. To omit undefined values from maps and structs instead, use
+// encoding.MarshalJSON.
+func (*undefined) MarshalJSON() ([]byte, error) { return json.Marshal(nil) }
+
 //go:generate rm -f mock_Plugin.go mock_Plugin_Closer.go
 //go:generate mockery --inpackage --note "Generated code. DO NOT MODIFY." --name=Plugin
 //go:generate cp mock_Plugin_Closer.go.src mock_Plugin_Closer.go
@@ -60,6 +66,15 @@ type Plugin interface {
 	Get(reqs []*GetReq) ([]*GetResult, error)
 }
 
+// ExecFinisher is an optional interface for plugins that keep state for
+// each policy execution, keyed by GetReq.ExecId. ExecFinished is called
+// once an execution is over, so that its state can be released without
+// waiting for GetReq.ExecDeadline. Plugins can't rely on it being called,
+// since not every host calls it, and the deadline remains the fallback.
+type ExecFinisher interface {
+	ExecFinished(execId uint64) error
+}
+
 // GetReq are the arguments given to Get for an Plugin.
 type GetReq struct {
 	// ExecId is a unique ID representing the particular execution for this
@@ -90,6 +105,28 @@ type GetReq struct {
 	// to nil. If this is set and the plugin does not implement
 	// framework.New, an error is returned.
 	Context map[string]interface{}
+
+	// Receiver is used in place of Context for receivers that aren't
+	// objects, such as lists, which are passed to framework.NewValue.
+	Receiver interface{}
+
+	// ReceiverPath are the keys of the namespace that constructs the
+	// receiver from Context or Receiver, as returned in
+	// GetResult.ReceiverPath. The root constructs the receiver if this is
+	// empty.
+	ReceiverPath []string
+
+	// NestedReceivers is set by hosts that support Receiver and
+	// ReceiverPath. Without it, only objects returned from the root are
+	// marked callable, since the host would send calls on any other value
+	// to the root, which can't construct its receiver.
+	NestedReceivers bool
+}
+
+// HasReceiver returns true if the request is made on a receiver, in
+// Context or Receiver.
+func (g *GetReq) HasReceiver() bool {
+	return g.Context != nil || g.Receiver != nil
 }
 
 // GetKey is an individual key in the larger possible selector of the
@@ -128,6 +165,14 @@ type GetResult struct {
 	Value    interface{}            // Value compatible with lang/object.ToObject
 	Context  map[string]interface{} // Updated Context if it was sent
 	Callable bool                   // true if returned Value is callable
+
+	// Receiver is the updated receiver if it was sent in GetReq.Receiver,
+	// or if it is no longer an object.
+	Receiver interface{}
+
+	// ReceiverPath is set with Callable to the keys of the namespace that
+	// constructs receivers from Value, to be sent in GetReq.ReceiverPath.
+	ReceiverPath []string
 }
 
 // GetResultList is a wrapper around a slice of GetResult structures
@@ -54,3 +54,14 @@ func Test_Null_MarshalJSON(t *testing.T) {
 		t.Fatalf("unexpected response, marshal of Null should be \"null\", got %q", string(res))
 	}
 }
+
+func Test_Undefined_MarshalJSON(t *testing.T) {
+	res, err := json.Marshal(map[string]interface{}{"a": Undefined})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(res, []byte(`{"a":null}`)) {
+		t.Fatalf("unexpected response, marshal of Undefined should be \"null\", got %q", string(res))
+	}
+}
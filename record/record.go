@@ -0,0 +1,366 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+// Package record contains an sdk.Plugin middleware for recording the
+// traffic to a plugin, and an sdk.Plugin that replays recorded traffic.
+//
+// This allows policies to be tested offline against frozen plugin data:
+// a Recorder wraps a real plugin while Sentinel executes, the recording is
+// written to a file, and a Replay serves the recorded results later on
+// without access to the backing system of the plugin.
+//
+//	rec := &record.Recorder{Plugin: realPlugin}
+//	// ... serve and execute policies ...
+//	rec.Recording().WriteFile("testdata/plugin.json")
+//
+//	r, err := record.ReadFile("testdata/plugin.json")
+//	replay := &record.Replay{Recording: r}
+//
+// Recordings are stored as JSON, with values in the JSON form of the
+// protocol buffer messages used by the plugin RPC. The file contents are
+// stable: recording the same traffic twice writes identical files.
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/encoding"
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// Recording is a set of recorded plugin traffic.
+type Recording struct {
+	// Config is the configuration the plugin was configured with, if it
+	// was recorded.
+	Config *proto.Value
+
+	// Entries are the recorded requests and their responses. Requests
+	// are normalized so that only the selector, arguments and context
+	// remain, see Entry for more details.
+	Entries []*Entry
+}
+
+// Entry is a single recorded request and response.
+//
+// The instance, execution and key IDs as well as the execution deadline
+// are cleared from both the request and response, since these vary between
+// executions and have no bearing on the result.
+type Entry struct {
+	Request  *proto.Get_Request
+	Response *proto.Get_Response
+}
+
+// recordingJSON is the JSON structure of a recording file.
+type recordingJSON struct {
+	Config  json.RawMessage `json:"config,omitempty"`
+	Entries []entryJSON     `json:"entries"`
+}
+
+type entryJSON struct {
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// ReadFile reads a recording from the file at path.
+func ReadFile(path string) (*Recording, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Recording
+	if err := r.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("error reading recording %q: %s", path, err)
+	}
+
+	return &r, nil
+}
+
+// WriteFile writes the recording to the file at path.
+func (r *Recording) WriteFile(path string) error {
+	data, err := r.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// MarshalJSON implements json.Marshaler. Entries are sorted by their
+// request so that the output is stable.
+func (r *Recording) MarshalJSON() ([]byte, error) {
+	var result recordingJSON
+	if r.Config != nil {
+		v := protobuf.Clone(r.Config).(*proto.Value)
+		sortValue(v)
+
+		var err error
+		result.Config, err = protojson.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := sortedEntries(r.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Entries = make([]entryJSON, len(entries))
+	for i, e := range entries {
+		req, err := protojson.Marshal(e.Request)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := protojson.Marshal(e.Response)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Entries[i] = entryJSON{Request: req, Response: resp}
+	}
+
+	// MarshalIndent reformats the embedded messages as well, which
+	// removes the unstable whitespace protojson emits.
+	data, err := json.MarshalIndent(&result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Recording) UnmarshalJSON(data []byte) error {
+	var raw recordingJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.Config = nil
+	if len(raw.Config) > 0 {
+		r.Config = new(proto.Value)
+		if err := protojson.Unmarshal(raw.Config, r.Config); err != nil {
+			return fmt.Errorf("config: %s", err)
+		}
+	}
+
+	r.Entries = make([]*Entry, len(raw.Entries))
+	for i, e := range raw.Entries {
+		entry := &Entry{
+			Request:  new(proto.Get_Request),
+			Response: new(proto.Get_Response),
+		}
+		if err := protojson.Unmarshal(e.Request, entry.Request); err != nil {
+			return fmt.Errorf("entry %d request: %s", i, err)
+		}
+		if err := protojson.Unmarshal(e.Response, entry.Response); err != nil {
+			return fmt.Errorf("entry %d response: %s", i, err)
+		}
+
+		r.Entries[i] = entry
+	}
+
+	return nil
+}
+
+// sortedEntries returns the entries sorted by the key of their request.
+func sortedEntries(entries []*Entry) ([]*Entry, error) {
+	keys := make(map[*Entry]string, len(entries))
+	for _, e := range entries {
+		k, err := requestKey(e.Request)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[e] = k
+	}
+
+	result := make([]*Entry, len(entries))
+	copy(result, entries)
+	sort.SliceStable(result, func(i, j int) bool {
+		return keys[result[i]] < keys[result[j]]
+	})
+
+	return result, nil
+}
+
+// requestKey returns the key used to match requests for replay. The
+// request must already be normalized with normalizeRequest.
+func requestKey(req *proto.Get_Request) (string, error) {
+	data, err := protobuf.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// encodeRequest converts the request to its normalized protobuf form.
+func encodeRequest(req *sdk.GetReq) (*proto.Get_Request, error) {
+	keys := make([]*proto.Get_Request_Key, len(req.Keys))
+	for i, reqKey := range req.Keys {
+		keys[i] = &proto.Get_Request_Key{Key: reqKey.Key}
+		if reqKey.Call() {
+			keys[i].Call = true
+			keys[i].Args = make([]*proto.Value, len(reqKey.Args))
+			for j, raw := range reqKey.Args {
+				v, err := encoding.GoToValue(raw)
+				if err != nil {
+					return nil, fmt.Errorf("error converting arg %d: %s", j, err)
+				}
+
+				sortValue(v)
+				keys[i].Args[j] = v
+			}
+		}
+	}
+
+	reqCtx, err := encodeContext(req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	reqReceiver, err := encodeReceiver(req.Receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Get_Request{
+		Keys:         keys,
+		Context:      reqCtx,
+		Receiver:     reqReceiver,
+		ReceiverPath: req.ReceiverPath,
+
+		NestedReceivers: req.NestedReceivers,
+	}, nil
+}
+
+// encodeResult converts the result to its normalized protobuf form.
+func encodeResult(result *sdk.GetResult) (*proto.Get_Response, error) {
+	v, err := encoding.GoToValue(result.Value)
+	if err != nil {
+		return nil, err
+	}
+	sortValue(v)
+
+	resCtx, err := encodeContext(result.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	resReceiver, err := encodeReceiver(result.Receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Get_Response{
+		Keys:         result.Keys,
+		Value:        v,
+		Context:      resCtx,
+		Callable:     result.Callable,
+		Receiver:     resReceiver,
+		ReceiverPath: result.ReceiverPath,
+	}, nil
+}
+
+// decodeResult converts a recorded response back to a result.
+func decodeResult(resp *proto.Get_Response) (*sdk.GetResult, error) {
+	v, err := encoding.ValueToGo(resp.Value, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resCtx map[string]interface{}
+	if resp.Context != nil {
+		resCtx = make(map[string]interface{})
+		for k, raw := range resp.Context {
+			v, err := encoding.ValueToGo(raw, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error converting context value for key %q: %s", k, err)
+			}
+
+			resCtx[k] = v
+		}
+	}
+
+	var resReceiver interface{}
+	if resp.Receiver != nil {
+		resReceiver, err = encoding.ValueToGo(resp.Receiver, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error converting receiver: %s", err)
+		}
+	}
+
+	return &sdk.GetResult{
+		Keys:         resp.Keys,
+		Value:        v,
+		Context:      resCtx,
+		Callable:     resp.Callable,
+		Receiver:     resReceiver,
+		ReceiverPath: resp.ReceiverPath,
+	}, nil
+}
+
+func encodeContext(ctx map[string]interface{}) (map[string]*proto.Value, error) {
+	if ctx == nil {
+		return nil, nil
+	}
+
+	result := make(map[string]*proto.Value)
+	for k, raw := range ctx {
+		v, err := encoding.GoToValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("error converting context value for key %q: %s", k, err)
+		}
+
+		sortValue(v)
+		result[k] = v
+	}
+
+	return result, nil
+}
+
+func encodeReceiver(receiver interface{}) (*proto.Value, error) {
+	if receiver == nil {
+		return nil, nil
+	}
+
+	v, err := encoding.GoToValue(receiver)
+	if err != nil {
+		return nil, fmt.Errorf("error converting receiver: %s", err)
+	}
+
+	sortValue(v)
+	return v, nil
+}
+
+// sortValue sorts the elements of all maps within v by their key, so that
+// the encoding of v is stable. GoToValue already sorts maps, but values
+// in a recording may have been edited by hand.
+func sortValue(v *proto.Value) {
+	switch v.Type {
+	case proto.Value_LIST:
+		for _, elem := range v.GetValueList().GetElems() {
+			sortValue(elem)
+		}
+
+	case proto.Value_MAP:
+		elems := v.GetValueMap().GetElems()
+		for _, elem := range elems {
+			sortValue(elem.Value)
+		}
+
+		sort.SliceStable(elems, func(i, j int) bool {
+			return encoding.CompareValues(elems[i].Key, elems[j].Key) < 0
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package record
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+)
+
+func TestRecorder_impl(t *testing.T) {
+	var _ sdk.Plugin = new(Recorder)
+	var _ sdk.ExecFinisher = new(Recorder)
+	var _ sdk.Plugin = new(Replay)
+}
+
+func TestRecordReplay(t *testing.T) {
+	reqs := []*sdk.GetReq{
+		{
+			KeyId: 1,
+			Keys: []sdk.GetKey{
+				{Key: "foo"},
+			},
+		},
+		{
+			KeyId: 2,
+			Keys: []sdk.GetKey{
+				{Key: "bar"},
+				{
+					Key: "baz",
+					Args: []interface{}{
+						"a",
+						map[string]interface{}{"x": int64(1), "y": int64(2), "z": int64(3)},
+					},
+				},
+			},
+		},
+	}
+
+	results := []*sdk.GetResult{
+		{
+			KeyId: 2,
+			Keys:  []string{"bar", "baz"},
+			Value: map[string]interface{}{
+				"a": int64(1),
+				"b": "two",
+				"c": []interface{}{true, 4.5},
+			},
+		},
+		{
+			KeyId: 1,
+			Keys:  []string{"foo"},
+			Value: "foo!",
+		},
+	}
+
+	pluginMock := new(sdk.MockPlugin)
+	pluginMock.On("Configure", map[string]interface{}{"key": "value"}).Return(nil)
+	pluginMock.On("Get", reqs).Return(results, nil)
+
+	rec := &Recorder{Plugin: pluginMock}
+	if err := rec.Configure(map[string]interface{}{"key": "value"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	actual, err := rec.Get(reqs)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(actual, results) {
+		t.Fatalf("bad: %#v", actual)
+	}
+	pluginMock.AssertExpectations(t)
+
+	// Write the recording and verify that encoding it is stable
+	path := filepath.Join(t.TempDir(), "recording.json")
+	if err := rec.Recording().WriteFile(path); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	recording, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	first, err := rec.Recording().MarshalJSON()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	second, err := recording.MarshalJSON()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatalf("recording not stable:\n\n%s\n\n%s", first, second)
+	}
+
+	// Replay, using different key IDs to the recording
+	replay := &Replay{Recording: recording}
+	if err := replay.Configure(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	replayed, err := replay.Get([]*sdk.GetReq{
+		{
+			KeyId: 42,
+			Keys: []sdk.GetKey{
+				{Key: "bar"},
+				{
+					Key: "baz",
+					Args: []interface{}{
+						"a",
+						map[string]interface{}{"z": 3, "y": 2, "x": 1},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []*sdk.GetResult{
+		{
+			KeyId: 42,
+			Keys:  []string{"bar", "baz"},
+			Value: map[string]interface{}{
+				"a": int64(1),
+				"b": "two",
+				"c": []interface{}{true, 4.5},
+			},
+		},
+	}
+	if !reflect.DeepEqual(replayed, expected) {
+		t.Fatalf("bad: %#v", replayed)
+	}
+}
+
+func TestRecordReplay_receiver(t *testing.T) {
+	req := func(receiver interface{}) *sdk.GetReq {
+		return &sdk.GetReq{
+			KeyId:        1,
+			Keys:         []sdk.GetKey{{Key: "filter", Args: []interface{}{"a"}}},
+			Receiver:     receiver,
+			ReceiverPath: []string{"resource", "tags"},
+
+			NestedReceivers: true,
+		}
+	}
+
+	results := []*sdk.GetResult{
+		{
+			KeyId:        1,
+			Keys:         []string{"filter"},
+			Value:        "a",
+			Callable:     true,
+			Receiver:     []interface{}{"a", int64(1)},
+			ReceiverPath: []string{"resource", "tags"},
+		},
+	}
+
+	reqs := []*sdk.GetReq{req([]interface{}{"a", int64(1)})}
+	pluginMock := new(sdk.MockPlugin)
+	pluginMock.On("Get", reqs).Return(results, nil)
+
+	rec := &Recorder{Plugin: pluginMock}
+	if _, err := rec.Get(reqs); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	replay := &Replay{Recording: rec.Recording()}
+	replayed, err := replay.Get([]*sdk.GetReq{req([]interface{}{"a", int64(1)})})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(replayed, results) {
+		t.Fatalf("bad: %#v", replayed[0])
+	}
+
+	// A different receiver is a different request
+	if _, err := replay.Get([]*sdk.GetReq{req([]interface{}{"b"})}); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestReplay_missing(t *testing.T) {
+	reqs := []*sdk.GetReq{
+		{
+			KeyId: 1,
+			Keys:  []sdk.GetKey{{Key: "nope"}},
+		},
+	}
+
+	replay := &Replay{Recording: &Recording{}}
+	if _, err := replay.Get(reqs); err == nil {
+		t.Fatal("should error")
+	}
+
+	replay = &Replay{Recording: &Recording{}, AllowMissing: true}
+	actual, err := replay.Get(reqs)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := []*sdk.GetResult{
+		{
+			KeyId: 1,
+			Keys:  []string{"nope"},
+			Value: sdk.Undefined,
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package record
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	"github.com/hashicorp/sentinel-sdk/encoding"
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// Recorder is an sdk.Plugin that wraps another plugin and records all
+// of the traffic to it. Requests are passed through to the wrapped plugin
+// unaltered.
+//
+// If the same request is made more than once, only the most recent
+// response is kept.
+type Recorder struct {
+	// Plugin is the plugin being recorded.
+	Plugin sdk.Plugin
+
+	config  *proto.Value
+	entries map[string]*Entry
+	lock    sync.Mutex
+}
+
+// plugin.Plugin impl.
+func (r *Recorder) Configure(config map[string]interface{}) error {
+	v, err := encoding.GoToValue(config)
+	if err != nil {
+		return fmt.Errorf("error recording config: %s", err)
+	}
+	sortValue(v)
+
+	r.lock.Lock()
+	r.config = v
+	r.lock.Unlock()
+
+	return r.Plugin.Configure(config)
+}
+
+// plugin.Plugin impl.
+func (r *Recorder) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
+	results, err := r.Plugin.Get(reqs)
+	if err != nil {
+		return results, err
+	}
+
+	// Match the results up to their requests and record them.
+	resultList := sdk.GetResultList(results)
+	entries := make(map[string]*Entry, len(reqs))
+	for _, req := range reqs {
+		result := resultList.KeyId(req.KeyId)
+		if result == nil {
+			continue
+		}
+
+		entry := &Entry{}
+		entry.Request, err = encodeRequest(req)
+		if err != nil {
+			return nil, fmt.Errorf("error recording request: %s", err)
+		}
+
+		entry.Response, err = encodeResult(result)
+		if err != nil {
+			return nil, fmt.Errorf("error recording result: %s", err)
+		}
+
+		key, err := requestKey(entry.Request)
+		if err != nil {
+			return nil, fmt.Errorf("error recording request: %s", err)
+		}
+
+		entries[key] = entry
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.entries == nil {
+		r.entries = make(map[string]*Entry)
+	}
+	for k, v := range entries {
+		r.entries[k] = v
+	}
+
+	return results, nil
+}
+
+// Close closes the wrapped plugin if it implements io.Closer.
+func (r *Recorder) Close() error {
+	if c, ok := r.Plugin.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// ExecFinished passes the end of an execution on to the wrapped plugin if
+// it implements sdk.ExecFinisher.
+func (r *Recorder) ExecFinished(execId uint64) error {
+	if f, ok := r.Plugin.(sdk.ExecFinisher); ok {
+		return f.ExecFinished(execId)
+	}
+
+	return nil
+}
+
+// Recording returns the traffic recorded so far.
+func (r *Recorder) Recording() *Recording {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	result := &Recording{
+		Config:  r.config,
+		Entries: make([]*Entry, 0, len(r.entries)),
+	}
+	for _, e := range r.entries {
+		result.Entries = append(result.Entries, e)
+	}
+
+	// Errors can only come from marshaling, which has already been done
+	// successfully for each of these requests when they were recorded.
+	result.Entries, _ = sortedEntries(result.Entries)
+	return result
+}
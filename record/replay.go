@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package record
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	sdk "github.com/hashicorp/sentinel-sdk"
+	proto "github.com/hashicorp/sentinel-sdk/proto/go"
+)
+
+// Replay is an sdk.Plugin that serves the results of a recording.
+//
+// Requests are matched to recorded requests by their keys, the arguments
+// of any calls, and the receiver context. The configuration given to
+// Replay is ignored.
+type Replay struct {
+	// Recording is the recording to serve results from.
+	Recording *Recording
+
+	// AllowMissing, if set, returns undefined for requests that were not
+	// recorded. Otherwise, an error is returned.
+	AllowMissing bool
+
+	entries map[string]*Entry
+	once    sync.Once
+	initErr error
+}
+
+// plugin.Plugin impl.
+func (r *Replay) Configure(map[string]interface{}) error {
+	r.once.Do(r.init)
+	return r.initErr
+}
+
+// plugin.Plugin impl.
+func (r *Replay) Get(reqs []*sdk.GetReq) ([]*sdk.GetResult, error) {
+	r.once.Do(r.init)
+	if r.initErr != nil {
+		return nil, r.initErr
+	}
+
+	resp := make([]*sdk.GetResult, len(reqs))
+	for i, req := range reqs {
+		encoded, err := encodeRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := requestKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		entry, ok := r.entries[key]
+		if !ok {
+			if !r.AllowMissing {
+				return nil, fmt.Errorf(
+					"no recorded result for key %q",
+					strings.Join(req.GetKeys(), "."))
+			}
+
+			resp[i] = &sdk.GetResult{
+				KeyId: req.KeyId,
+				Keys:  req.GetKeys(),
+				Value: sdk.Undefined,
+			}
+			continue
+		}
+
+		result, err := decodeResult(entry.Response)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error decoding recorded result for key %q: %s",
+				strings.Join(req.GetKeys(), "."), err)
+		}
+
+		result.KeyId = req.KeyId
+		resp[i] = result
+	}
+
+	return resp, nil
+}
+
+// init builds the lookup table of recorded entries.
+func (r *Replay) init() {
+	r.entries = make(map[string]*Entry)
+	if r.Recording == nil {
+		return
+	}
+
+	for _, e := range r.Recording.Entries {
+		// Normalize the request in case the recording was edited by hand.
+		// The recorded IDs are meaningless for matching.
+		req := protobuf.Clone(e.Request).(*proto.Get_Request)
+		req.InstanceId, req.ExecId, req.ExecDeadline, req.KeyId = 0, 0, 0, 0
+		for _, k := range req.Keys {
+			for _, arg := range k.Args {
+				sortValue(arg)
+			}
+		}
+		for _, v := range req.Context {
+			sortValue(v)
+		}
+
+		key, err := requestKey(req)
+		if err != nil {
+			r.initErr = fmt.Errorf("error loading recording: %s", err)
+			return
+		}
+
+		r.entries[key] = e
+	}
+}
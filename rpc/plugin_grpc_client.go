@@ -9,6 +9,8 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	sdk "github.com/hashicorp/sentinel-sdk"
 	"github.com/hashicorp/sentinel-sdk/encoding"
@@ -33,6 +35,25 @@ func (m *PluginGRPCClient) Close() error {
 	return nil
 }
 
+func (m *PluginGRPCClient) ExecFinished(execId uint64) error {
+	if m.instanceId == 0 {
+		return nil
+	}
+
+	_, err := m.Client.ExecFinished(context.Background(), &proto.ExecFinished_Request{
+		InstanceId: m.instanceId,
+		ExecId:     execId,
+	})
+
+	// Plugins built with an older SDK don't implement the call, and
+	// release the state of executions at their deadline instead.
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+
+	return err
+}
+
 func (m *PluginGRPCClient) Configure(config map[string]interface{}) error {
 	v, err := encoding.GoToValue(config)
 	if err != nil {
@@ -85,6 +106,16 @@ func (m *PluginGRPCClient) Get(rawReqs []*sdk.GetReq) ([]*sdk.GetResult, error)
 			}
 		}
 
+		// Request receiver, if it isn't an object
+		var reqReceiver *proto.Value
+		if req.Receiver != nil {
+			var err error
+			reqReceiver, err = encoding.GoToValue(req.Receiver)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		reqs = append(reqs, &proto.Get_Request{
 			InstanceId:   m.instanceId,
 			ExecId:       req.ExecId,
@@ -92,6 +123,10 @@ func (m *PluginGRPCClient) Get(rawReqs []*sdk.GetReq) ([]*sdk.GetResult, error)
 			Keys:         keys,
 			KeyId:        req.KeyId,
 			Context:      reqCtx,
+			Receiver:     reqReceiver,
+			ReceiverPath: req.ReceiverPath,
+
+			NestedReceivers: req.NestedReceivers,
 		})
 	}
 
@@ -128,12 +163,23 @@ func (m *PluginGRPCClient) Get(rawReqs []*sdk.GetReq) ([]*sdk.GetResult, error)
 			}
 		}
 
+		// Response receiver, if it isn't an object
+		var resReceiver interface{}
+		if resp.Receiver != nil {
+			resReceiver, err = encoding.ValueToGo(resp.Receiver, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error converting receiver: %s", err)
+			}
+		}
+
 		results = append(results, &sdk.GetResult{
-			KeyId:    resp.KeyId,
-			Keys:     resp.Keys,
-			Value:    v,
-			Context:  resCtx,
-			Callable: resp.Callable,
+			KeyId:        resp.KeyId,
+			Keys:         resp.Keys,
+			Value:        v,
+			Context:      resCtx,
+			Callable:     resp.Callable,
+			Receiver:     resReceiver,
+			ReceiverPath: resp.ReceiverPath,
 		})
 	}
 
@@ -13,4 +13,5 @@ import (
 func TestPluginGRPCClient_impl(t *testing.T) {
 	var _ sdk.Plugin = new(PluginGRPCClient)
 	var _ io.Closer = new(PluginGRPCClient)
+	var _ sdk.ExecFinisher = new(PluginGRPCClient)
 }
@@ -48,6 +48,25 @@ func (m *PluginGRPCServer) Close(
 	return &proto.Empty{}, nil
 }
 
+func (m *PluginGRPCServer) ExecFinished(
+	ctx context.Context, v *proto.ExecFinished_Request) (*proto.Empty, error) {
+	m.instancesLock.RLock()
+	impt, ok := m.instances[v.InstanceId]
+	m.instancesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown instance ID given: %d", v.InstanceId)
+	}
+
+	// Plugins that don't keep state per execution have nothing to do.
+	if f, ok := impt.(sdk.ExecFinisher); ok {
+		if err := f.ExecFinished(v.ExecId); err != nil {
+			return nil, err
+		}
+	}
+
+	return &proto.Empty{}, nil
+}
+
 func (m *PluginGRPCServer) Configure(
 	ctx context.Context, v *proto.Configure_Request) (*proto.Configure_Response, error) {
 	// Build the configuration
@@ -120,12 +139,26 @@ func (m *PluginGRPCServer) Get(
 			}
 		}
 
+		// Object receiver, if it isn't an object
+		var reqReceiver interface{}
+		if req.Receiver != nil {
+			var err error
+			reqReceiver, err = encoding.ValueToGo(req.Receiver, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error converting receiver: %s", err)
+			}
+		}
+
 		getReq := &sdk.GetReq{
 			ExecId:       req.ExecId,
 			ExecDeadline: time.Unix(int64(req.ExecDeadline), 0),
 			Keys:         keys,
 			KeyId:        req.KeyId,
 			Context:      reqCtx,
+			Receiver:     reqReceiver,
+			ReceiverPath: req.ReceiverPath,
+
+			NestedReceivers: req.NestedReceivers,
 		}
 
 		requestsById[req.InstanceId] = append(requestsById[req.InstanceId], getReq)
@@ -166,13 +199,24 @@ func (m *PluginGRPCServer) Get(
 				}
 			}
 
+			// Return receiver, if it isn't an object
+			var resReceiver *proto.Value
+			if result.Receiver != nil {
+				resReceiver, err = encoding.GoToValue(result.Receiver)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			responses = append(responses, &proto.Get_Response{
-				InstanceId: id,
-				KeyId:      result.KeyId,
-				Keys:       result.Keys,
-				Value:      v,
-				Context:    resCtx,
-				Callable:   result.Callable,
+				InstanceId:   id,
+				KeyId:        result.KeyId,
+				Keys:         result.Keys,
+				Value:        v,
+				Context:      resCtx,
+				Callable:     result.Callable,
+				Receiver:     resReceiver,
+				ReceiverPath: result.ReceiverPath,
 			})
 		}
 	}
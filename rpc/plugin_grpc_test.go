@@ -93,6 +93,34 @@ func TestPlugin_gRPC_get(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "receiver",
+			Requests: []*sdk.GetReq{
+				{
+					KeyId: 42,
+					Keys: []sdk.GetKey{
+						{
+							Key:  "filter",
+							Args: []interface{}{"a"},
+						},
+					},
+					Receiver:     []interface{}{"a", int64(1)},
+					ReceiverPath: []string{"resource", "tags"},
+
+					NestedReceivers: true,
+				},
+			},
+			Results: []*sdk.GetResult{
+				{
+					KeyId:        42,
+					Keys:         []string{"filter"},
+					Value:        "a",
+					Receiver:     []interface{}{"a", int64(1)},
+					Callable:     true,
+					ReceiverPath: []string{"resource", "tags"},
+				},
+			},
+		},
 		{
 			Name: "not a function",
 			Requests: []*sdk.GetReq{
@@ -154,3 +182,48 @@ func TestPlugin_gRPC_get(t *testing.T) {
 		})
 	}
 }
+
+func TestPlugin_gRPC_execFinished(t *testing.T) {
+	pluginMock := new(mockPluginExecFinisher)
+	pluginMock.On("Configure", map[string]interface{}{}).Return(nil)
+	pluginMock.On("ExecFinished", uint64(42)).Return(nil)
+
+	obj, closer := testPluginServeGRPC(t, pluginMock)
+	defer closer()
+
+	if err := obj.Configure(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	err := obj.(sdk.ExecFinisher).ExecFinished(42)
+	pluginMock.AssertExpectations(t)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestPlugin_gRPC_execFinishedUnsupported(t *testing.T) {
+	pluginMock := new(sdk.MockPlugin)
+	pluginMock.On("Configure", map[string]interface{}{}).Return(nil)
+
+	obj, closer := testPluginServeGRPC(t, pluginMock)
+	defer closer()
+
+	if err := obj.Configure(nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := obj.(sdk.ExecFinisher).ExecFinished(42); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// mockPluginExecFinisher augments MockPlugin to also implement
+// sdk.ExecFinisher.
+type mockPluginExecFinisher struct {
+	sdk.MockPlugin
+}
+
+func (m *mockPluginExecFinisher) ExecFinished(execId uint64) error {
+	return m.Called(execId).Error(0)
+}
@@ -0,0 +1,235 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package testing
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultBuildOptions are the build options used for any TestPluginCase
+// that does not set its own Build field. This may be altered in TestMain
+// to change how plugins are built for an entire test run.
+var DefaultBuildOptions BuildOptions
+
+// BuildOptions control how the plugin binary under test is built.
+type BuildOptions struct {
+	// Binary is the path to a prebuilt plugin binary. If this is set, no
+	// build is performed and all other options are ignored.
+	Binary string
+
+	// Main is the path to a custom template for the main.go used to
+	// build the plugin. Any occurrence of "PATH" in the template is
+	// replaced with the Go import path of the plugin. If this is blank,
+	// the built-in template is used, which serves the "New" function
+	// of the plugin package.
+	Main string
+
+	// Tags is a list of build tags passed to "go build -tags".
+	Tags []string
+
+	// LDFlags is passed to "go build -ldflags".
+	LDFlags string
+
+	// Race enables the race detector in the plugin binary.
+	Race bool
+
+	// Cover builds the plugin binary with coverage instrumentation. The
+	// coverage data written by the plugin is collected after each test
+	// case and, when the tests are run with coverage enabled, merged
+	// into the coverage profile by Clean.
+	//
+	// This is enabled automatically when the tests are run with
+	// coverage enabled, such as with "go test -cover".
+	Cover bool
+
+	// CoverMode is the coverage mode passed to "go build -covermode".
+	// This defaults to the coverage mode of the running test binary.
+	CoverMode string
+
+	// CoverPkg is the list of package patterns to instrument, passed to
+	// "go build -coverpkg". By default, the packages in the main module
+	// of the plugin are instrumented.
+	CoverPkg []string
+
+	// CoverDir, if set, is the directory the raw coverage data from the
+	// plugin binary is collected into instead of being merged into the
+	// coverage profile. The data can be inspected with "go tool covdata".
+	CoverDir string
+
+	// Flags are any further flags to pass to "go build".
+	Flags []string
+
+	// CacheDir, if set, is the directory used to cache built plugin
+	// binaries across test runs. Binaries are keyed by a hash of the
+	// plugin source, its dependencies, the Go toolchain, and the build
+	// options. Binaries are never removed from the directory, so it
+	// should be one that is cleaned up, such as a CI cache. If this is
+	// blank, binaries are only built once per test run.
+	CacheDir string
+}
+
+// args returns the arguments to "go build", excluding the output.
+func (o *BuildOptions) args() []string {
+	var args []string
+	if len(o.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(o.Tags, ","))
+	}
+
+	if o.LDFlags != "" {
+		args = append(args, "-ldflags", o.LDFlags)
+	}
+
+	if o.Race {
+		args = append(args, "-race")
+	}
+
+	if o.Cover {
+		args = append(args, "-cover")
+		if o.CoverMode != "" {
+			args = append(args, "-covermode", o.CoverMode)
+		}
+
+		if len(o.CoverPkg) > 0 {
+			args = append(args, "-coverpkg", strings.Join(o.CoverPkg, ","))
+		}
+	}
+
+	return append(args, o.Flags...)
+}
+
+// key returns the key used to identify the binary for the plugin path
+// built with these options within a single test run.
+func (o *BuildOptions) key(path string) string {
+	return strings.Join(append([]string{path, o.Main}, o.args()...), "\x00")
+}
+
+// buildHash computes a hash for the build of the main package in dir. The
+// hash covers the Go toolchain, the build arguments, and the contents of
+// every non-standard package the main package depends on, including the
+// main package itself.
+func buildHash(dir string, o *BuildOptions) (string, error) {
+	h := sha256.New()
+
+	cmd := exec.Command("go", "env", "GOVERSION", "GOOS", "GOARCH", "CGO_ENABLED")
+	cmd.Dir = dir
+	env, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error reading go env: %s", err)
+	}
+	h.Write(env)
+	fmt.Fprintf(h, "%q\n", o.args())
+
+	args := []string{"list", "-deps"}
+	if len(o.Tags) > 0 {
+		args = append(args, "-tags", strings.Join(o.Tags, ","))
+	}
+	args = append(args, "-f", buildHashTemplate, ".")
+	cmd = exec.Command("go", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error listing plugin dependencies: %s", err)
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		parts := strings.Split(s.Text(), "|")
+		if parts[0] == "" {
+			continue
+		}
+
+		// The main package lives in a temporary directory, so only its
+		// file names and contents are included in the hash.
+		pkgDir := parts[0]
+		if pkgDir == dir {
+			fmt.Fprintln(h, "main")
+		} else {
+			fmt.Fprintln(h, pkgDir)
+		}
+
+		for _, name := range parts[1:] {
+			f, err := os.Open(filepath.Join(pkgDir, name))
+			if err != nil {
+				return "", err
+			}
+
+			fmt.Fprintln(h, name)
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// buildHashTemplate is the "go list" template for the files hashed by
+// buildHash: the directory of each package followed by the names of all
+// the files it is built from, separated by "|".
+var buildHashTemplate = `{{if not .Standard}}{{.Dir}}` +
+	buildHashFiles("GoFiles", "CgoFiles", "CFiles", "CXXFiles", "MFiles",
+		"HFiles", "FFiles", "SFiles", "SwigFiles", "SwigCXXFiles",
+		"SysoFiles", "EmbedFiles") +
+	`{{end}}`
+
+func buildHashFiles(lists ...string) string {
+	var b strings.Builder
+	for _, list := range lists {
+		fmt.Fprintf(&b, "{{range .%s}}|{{.}}{{end}}", list)
+	}
+
+	return b.String()
+}
+
+// cacheBinary copies the binary at src into the cache at dst. The copy
+// is written to a temporary file first and renamed into place so that a
+// concurrent test run never sees a partially written binary.
+func cacheBinary(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := ioutil.TempFile(filepath.Dir(dst), "tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(out.Name(), 0755); err != nil {
+		return err
+	}
+
+	return os.Rename(out.Name(), dst)
+}
@@ -0,0 +1,145 @@
+// Copyright IBM Corp. 2017, 2025
+// SPDX-License-Identifier: MPL-2.0
+
+package testing
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	gotesting "testing"
+)
+
+// pluginCoverDir is the directory that coverage data from instrumented
+// plugin binaries is collected into over the test run. This is merged
+// into the coverage profile of the test binary by Clean.
+//
+// This is guarded by pluginLock.
+var pluginCoverDir string
+
+// coverOptions enables coverage instrumentation in opts if the running
+// test binary is collecting coverage, matching its coverage mode.
+func coverOptions(opts *BuildOptions) {
+	mode := gotesting.CoverMode()
+	if mode == "" {
+		return
+	}
+
+	opts.Cover = true
+	if opts.CoverMode == "" {
+		opts.CoverMode = mode
+	}
+}
+
+// collectCoverage moves the coverage data written by a plugin binary to
+// dir into the directory given by the build options, or the directory
+// merged by Clean if none was given.
+func collectCoverage(opts *BuildOptions, dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	dst := opts.CoverDir
+	if dst == "" {
+		pluginLock.Lock()
+		defer pluginLock.Unlock()
+
+		if pluginCoverDir == "" {
+			td, err := ioutil.TempDir("", "sentinel-sdk-cover")
+			if err != nil {
+				return err
+			}
+
+			pluginCoverDir = td
+		}
+
+		dst = pluginCoverDir
+	}
+
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	// The data files are uniquely named by the Go runtime. Meta-data
+	// files are named after the hash of their contents, so overwriting
+	// one is harmless.
+	for _, fi := range files {
+		src := filepath.Join(dir, fi.Name())
+		if err := os.Rename(src, filepath.Join(dst, fi.Name())); err == nil {
+			continue
+		}
+
+		// The rename can fail across file systems, fall back to copying.
+		data, err := ioutil.ReadFile(src)
+		if err != nil {
+			return err
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(dst, fi.Name()), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeCoverage appends the coverage data collected in pluginCoverDir to
+// the coverage profile of the running test binary, if it is writing one.
+// The profile is only written once all tests have completed, so this must
+// be called after testing.M.Run returns.
+//
+// pluginLock must be held by the caller.
+func mergeCoverage() error {
+	if pluginCoverDir == "" {
+		return nil
+	}
+
+	f := flag.Lookup("test.coverprofile")
+	if f == nil || f.Value.String() == "" {
+		return nil
+	}
+
+	// Relative profile paths are relative to the output directory,
+	// mirroring the testing package.
+	profile := f.Value.String()
+	if !filepath.IsAbs(profile) {
+		if dir := flag.Lookup("test.outputdir"); dir != nil && dir.Value.String() != "" {
+			profile = filepath.Join(dir.Value.String(), profile)
+		}
+	}
+
+	// Convert the binary coverage data to the text format
+	out := filepath.Join(pluginCoverDir, "profile.txt")
+	cmd := exec.Command("go", "tool", "covdata", "textfmt",
+		"-i="+pluginCoverDir, "-o="+out)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error converting coverage data: %s\n\n%s", err, output)
+	}
+
+	data, err := ioutil.ReadFile(out)
+	if err != nil {
+		return err
+	}
+
+	// Strip the mode header, the profile being merged into has its own.
+	if idx := bytes.IndexByte(data, '\n'); bytes.HasPrefix(data, []byte("mode:")) && idx >= 0 {
+		data = data[idx+1:]
+	}
+
+	fh, err := os.OpenFile(profile, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fh.Write(data); err != nil {
+		fh.Close()
+		return err
+	}
+
+	return fh.Close()
+}
@@ -16,7 +16,10 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	gotesting "testing"
 	"text/scanner"
 
 	"github.com/mitchellh/go-testing-interface"
@@ -25,11 +28,26 @@ import (
 //go:embed data
 var content embed.FS
 
-// pluginMap is the list of built plugin binaries keyed by plugin path.
-// This plugin path should be canonicalized via PluginPath.
-var pluginBuildDir string
-var pluginMap = map[string]string{}
-var pluginErr = map[string]error{}
+// pluginBuilds are the builds of plugin binaries keyed by plugin path
+// and build options (see BuildOptions.key). This plugin path should be
+// canonicalized via PluginPath.
+//
+// pluginLock guards all of the build state below, as test cases may be
+// executed in parallel. It isn't held while building, so that plugins
+// with different keys are built concurrently.
+var (
+	pluginBuildDir string
+	pluginBuilds   = map[string]*pluginBuild{}
+	pluginLock     sync.Mutex
+)
+
+// pluginBuild is the build of a plugin binary, done once for all the test
+// cases that use it.
+type pluginBuild struct {
+	once sync.Once
+	path string
+	err  error
+}
 
 // TestPluginCase is a single test case for configuring TestPlugin.
 type TestPluginCase struct {
@@ -58,10 +76,14 @@ type TestPluginCase struct {
 	// This should usually be blank. This maximizes portability of the
 	// plugin if it were to be forked or moved.
 	//
-	// For a given plugin path, the test binary will be built exactly once
-	// per test run.
+	// For a given plugin path and set of build options, the test binary
+	// will be built exactly once per test run.
 	PluginPath string
 
+	// Build are the options used to build the plugin binary. If this is
+	// nil, DefaultBuildOptions is used.
+	Build *BuildOptions
+
 	// PluginName allows passing a custom name for the plugin to be used in
 	// test cases. By default, the plugin is simply named "subject". The
 	// plugin name is what is used within this policy's source to access
@@ -80,6 +102,12 @@ type TestPluginCase struct {
 	// against the resulting pattern. If a match is found, the test passes.
 	// If it does not match, the tests will fail.
 	Error string
+
+	// Parallel, if set, signals that this test case may be run in parallel
+	// with other test cases. This is only honored by TestPluginDir, and
+	// only when it is given a *testing.T from the standard library. The
+	// case then runs after the calling test function returns, see Clean.
+	Parallel bool
 }
 
 // LoadTestPluginCase is used to load a TestPluginCase from a Sentinel policy
@@ -164,6 +192,18 @@ func LoadTestPluginCase(t testing.T, path string) TestPluginCase {
 // TestPluginDir iterates over files in a directory, calls
 // LoadTestPluginCase on each file suffixed with ".sentinel", and executes all
 // of the plugin tests.
+//
+// If t is a *testing.T from the standard library, each file is executed as
+// a subtest named after the file. This allows cases to be filtered with
+// -run and executed in parallel if TestPluginCase.Parallel is set.
+// Parallel subtests only run once the calling test function returns, so
+// TestPluginDir returns before they finish. Clean must not be deferred in
+// the calling test function, since it would delete the plugin binaries
+// before the subtests run; call it from TestMain instead.
+//
+// Otherwise, the files are executed sequentially. A failure in one file does
+// not stop the others from executing; all failures are reported together
+// once every file has been checked.
 func TestPluginDir(t testing.T, path string, customize func(*TestPluginCase)) {
 	files, err := ioutil.ReadDir(path)
 	if err != nil {
@@ -195,47 +235,168 @@ func TestPluginDir(t testing.T, path string, customize func(*TestPluginCase)) {
 		cases[fi.Name()] = tc
 	}
 
-	// Run all of the tests.
-	for file, tc := range cases {
-		// The testing interface (mitchellh/go-testing-interface) doesn't
-		// support a t.Run(), and adding context about which policy is failing
-		// to the error is obtuse otherwise, so we'll just log the policy file
-		// name here to give that context to the developer.
+	// Sort the file names so that the execution and reporting order is
+	// stable between runs.
+	names := make([]string, 0, len(cases))
+	for file := range cases {
+		names = append(names, file)
+	}
+	sort.Strings(names)
+
+	// If we have the standard library testing.T, we can run each file as
+	// a subtest. Subtests take care of reporting failures themselves.
+	if runner, ok := t.(interface {
+		Run(string, func(*gotesting.T)) bool
+	}); ok {
+		for _, file := range names {
+			tc := cases[file]
+			runner.Run(file, func(t *gotesting.T) {
+				if tc.Parallel {
+					t.Parallel()
+				}
+
+				TestPlugin(t, tc)
+			})
+		}
+
+		return
+	}
+
+	// The testing interface (mitchellh/go-testing-interface) doesn't
+	// support a t.Run(), so we run each case against a recorder which
+	// captures failures rather than halting, and report them all at the
+	// end with the name of the failing policy file for context.
+	var failed []string
+	for _, file := range names {
 		t.Logf("Checking %s ...", file)
-		TestPlugin(t, tc)
+		ct := &caseT{T: t, name: file}
+		ct.run(func() { TestPlugin(ct, cases[file]) })
+		if ct.Failed() {
+			failed = append(failed, file)
+		}
 	}
+
+	if len(failed) > 0 {
+		t.Fatalf("%d of %d policies failed: %s",
+			len(failed), len(names), strings.Join(failed, ", "))
+	}
+}
+
+// caseT wraps a testing.T for the execution of a single case in
+// TestPluginDir. Failures are logged with the case name and recorded
+// locally so that the remaining cases can continue to execute.
+type caseT struct {
+	testing.T
+
+	name   string
+	failed bool
+}
+
+// caseFailNow is the panic value used by caseT to halt a case.
+type caseFailNow struct{}
+
+// run executes f, recovering from any halt triggered by FailNow.
+func (t *caseT) run(f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(caseFailNow); !ok {
+				panic(r)
+			}
+		}
+	}()
+
+	f()
+}
+
+func (t *caseT) Error(args ...interface{}) {
+	t.Log(args...)
+	t.Fail()
+}
+
+func (t *caseT) Errorf(format string, args ...interface{}) {
+	t.Logf(format, args...)
+	t.Fail()
+}
+
+func (t *caseT) Fail()        { t.failed = true }
+func (t *caseT) Failed() bool { return t.failed }
+func (t *caseT) Name() string { return t.T.Name() + "/" + t.name }
+
+func (t *caseT) FailNow() {
+	t.Fail()
+	panic(caseFailNow{})
+}
+
+func (t *caseT) Fatal(args ...interface{}) {
+	t.Log(args...)
+	t.FailNow()
+}
+
+func (t *caseT) Fatalf(format string, args ...interface{}) {
+	t.Logf(format, args...)
+	t.FailNow()
+}
+
+func (t *caseT) Log(args ...interface{}) {
+	t.T.Log(append([]interface{}{t.name + ":"}, args...)...)
+}
+
+func (t *caseT) Logf(format string, args ...interface{}) {
+	t.T.Logf(t.name+": "+format, args...)
 }
 
 // Clean cleans any temporary files created. This should always be called
-// at the end of any set of plugin tests.
+// at the end of any set of plugin tests, once every test using a plugin
+// binary has finished, including parallel subtests. Calling it from
+// TestMain after testing.M.Run returns guarantees this.
+//
+// If the tests are run with coverage enabled, Clean also merges the
+// coverage collected from the plugin binaries into the coverage profile,
+// which also requires calling it after testing.M.Run returns.
 func Clean() {
+	pluginLock.Lock()
+	defer pluginLock.Unlock()
+
+	// Merge the plugin coverage into the profile
+	if err := mergeCoverage(); err != nil {
+		log.Printf("Error merging plugin coverage: %s", err)
+	}
+
 	// Delete our build directory
 	if pluginBuildDir != "" {
 		os.RemoveAll(pluginBuildDir)
 	}
 
+	// Delete our coverage directory
+	if pluginCoverDir != "" {
+		os.RemoveAll(pluginCoverDir)
+	}
+
 	// Reset all globals
-	pluginMap = map[string]string{}
-	pluginErr = map[string]error{}
+	pluginBuildDir = ""
+	pluginCoverDir = ""
+	pluginBuilds = map[string]*pluginBuild{}
 }
 
 // TestPlugin tests that a sdk.Plugin implementation works as expected.
 func TestPlugin(t testing.T, c TestPluginCase) {
-	// Infer the path
-	path, err := PluginPath(c.PluginPath)
-	if err != nil {
-		t.Fatalf("error inferring GOPATH: %s", err)
-	}
-
-	// If we already errored building this, report it
-	if err, ok := pluginErr[path]; ok {
-		t.Fatalf("error building plugin: %s", err)
-	}
+	opts := DefaultBuildOptions
+	if c.Build != nil {
+		opts = *c.Build
+	}
+	coverOptions(&opts)
+
+	// Use the prebuilt binary if we have one, otherwise get the path to
+	// the built plugin, or build it
+	binaryPath := opts.Binary
+	if binaryPath == "" {
+		// Infer the path
+		path, err := PluginPath(c.PluginPath)
+		if err != nil {
+			t.Fatalf("error inferring GOPATH: %s", err)
+		}
 
-	// Get the path to the built plugin, or build it
-	binaryPath, ok := pluginMap[path]
-	if !ok {
-		binaryPath = buildPlugin(t, path)
+		binaryPath = pluginBinary(t, path, &opts)
 	}
 
 	// Build the full source which requires importing the subject
@@ -277,10 +438,27 @@ func TestPlugin(t testing.T, c TestPluginCase) {
 		t.Fatalf("error writing config: %s", err)
 	}
 
-	// Execute Sentinel
+	// Execute Sentinel. If the plugin is instrumented for coverage, point
+	// it at a directory to write its coverage data to.
+	coverDir := filepath.Join(td, "cover")
 	cmd := exec.Command("sentinel", "apply", "-config", configPath, policyPath)
 	cmd.Dir = td
+	if opts.Cover {
+		if err := os.Mkdir(coverDir, 0755); err != nil {
+			t.Fatalf("error creating coverage directory: %s", err)
+		}
+
+		cmd.Env = append(os.Environ(), "GOCOVERDIR="+coverDir)
+	}
 	output, err := cmd.CombinedOutput()
+
+	// Collect the coverage data before checking the result, so that the
+	// coverage of failing policies is still counted.
+	if opts.Cover {
+		if err := collectCoverage(&opts, coverDir); err != nil {
+			t.Fatalf("error collecting coverage data: %s", err)
+		}
+	}
 	if err != nil {
 		if c.Error != "" {
 			if c.Error[:1]+c.Error[len(c.Error)-1:] == "//" {
@@ -384,44 +562,69 @@ func PluginPath(dir string) (string, error) {
 	return path, nil
 }
 
-// buildPlugin compiles the plugin binary with the given Go import path.
-// The path to the completed binary is inserted into the global pluginMap.
-func buildPlugin(t testing.T, path string) string {
+// pluginBinary returns the path to the built binary for the plugin at
+// the given Go import path, building it if it has not been built yet with
+// the given options. This is safe to call concurrently; a plugin is only
+// ever built once per set of options.
+func pluginBinary(t testing.T, path string, opts *BuildOptions) string {
+	key := opts.key(path)
+
+	pluginLock.Lock()
+	b, ok := pluginBuilds[key]
+	if !ok {
+		b = &pluginBuild{}
+		pluginBuilds[key] = b
+	}
+	pluginLock.Unlock()
+
+	// Only the first caller builds, the others wait for it. If we
+	// already errored building this, report it.
+	b.once.Do(func() {
+		b.path, b.err = buildPlugin(path, opts)
+	})
+	if b.err != nil {
+		t.Fatalf("error building plugin: %s", b.err)
+	}
+
+	return b.path
+}
+
+// buildPlugin compiles the plugin binary with the given Go import path,
+// returning the path to the completed binary.
+//
+// If caching is enabled in the build options, a previously built binary
+// with matching inputs is used from the cache instead of building.
+func buildPlugin(path string, opts *BuildOptions) (string, error) {
 	log.Printf("Building binary: %s", path)
 
-	tpl, err := content.ReadFile("data/main.go.tpl")
+	var tpl []byte
+	var err error
+	if opts.Main != "" {
+		tpl, err = ioutil.ReadFile(opts.Main)
+	} else {
+		tpl, err = content.ReadFile("data/main.go.tpl")
+	}
 	if err != nil {
-		t.Fatalf("err: %s", err)
+		return "", err
 	}
 	// Create the main.go
 	main := bytes.Replace(
 		tpl,
 		[]byte("PATH"), []byte(path), -1)
 
-	// If we don't have a build dir, make one
-	if pluginBuildDir == "" {
-		// Create the directory to compile this
-		wd, err := os.Getwd()
-		if err != nil {
-			t.Fatalf("err: %s", err)
-		}
-		td, err := ioutil.TempDir(wd, "sentinel-sdk")
-		if err != nil {
-			t.Fatalf("err: %s", err)
-		}
-
-		pluginBuildDir = td
-	}
-
 	// Create the build dir for this plugin
-	td, err := ioutil.TempDir(pluginBuildDir, "sentinel-sdk")
+	dir, err := buildDir()
 	if err != nil {
-		t.Fatalf("err: %s", err)
+		return "", err
+	}
+	td, err := ioutil.TempDir(dir, "sentinel-sdk")
+	if err != nil {
+		return "", err
 	}
 
 	// Write the file
 	if err := ioutil.WriteFile(filepath.Join(td, "main.go"), main, 0644); err != nil {
-		t.Fatalf("err: %s", err)
+		return "", err
 	}
 
 	// Build.  Note that when running on Windows systems the
@@ -431,18 +634,62 @@ func buildPlugin(t testing.T, path string) string {
 		buildOutput += ".exe"
 	}
 
-	cmd := exec.Command("go", "build", "-o", buildOutput)
+	// Look for the binary in the cache. Failing to compute the hash is
+	// not fatal, we just build without the cache.
+	var cachePath string
+	if dir := opts.CacheDir; dir != "" {
+		hash, err := buildHash(td, opts)
+		if err != nil {
+			log.Printf("Not caching plugin binary: %s", err)
+		} else {
+			cachePath = filepath.Join(dir, hash, buildOutput)
+			if _, err := os.Stat(cachePath); err == nil {
+				log.Printf("Plugin binary found in cache: %s", cachePath)
+				return cachePath, nil
+			}
+		}
+	}
+
+	args := append([]string{"build", "-o", buildOutput}, opts.args()...)
+	cmd := exec.Command("go", args...)
 	cmd.Dir = td
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		pluginErr[path] = err
-		t.Fatalf("err building the test binary. output:\n\n%s", string(output))
+		return "", fmt.Errorf("%s. output:\n\n%s", err, string(output))
+	}
+
+	binaryPath := filepath.Join(td, buildOutput)
+	log.Printf("Plugin binary built at: %s", binaryPath)
+
+	if cachePath != "" {
+		if err := cacheBinary(binaryPath, cachePath); err != nil {
+			log.Printf("Error caching plugin binary: %s", err)
+		}
+	}
+
+	return binaryPath, nil
+}
+
+// buildDir returns the directory the plugin binaries are built in,
+// creating it on first use.
+func buildDir() (string, error) {
+	pluginLock.Lock()
+	defer pluginLock.Unlock()
+
+	if pluginBuildDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		td, err := ioutil.TempDir(wd, "sentinel-sdk")
+		if err != nil {
+			return "", err
+		}
+
+		pluginBuildDir = td
 	}
 
-	// Record it
-	pluginMap[path] = filepath.Join(td, buildOutput)
-	log.Printf("Plugin binary built at: %s", pluginMap[path])
-	return pluginMap[path]
+	return pluginBuildDir, nil
 }
 
 func isWindows() bool {
@@ -5,7 +5,10 @@ package testing
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	testingiface "github.com/mitchellh/go-testing-interface"
@@ -128,4 +131,148 @@ func TestTestPlugin(t *testing.T) {
 			tc.Global = map[string]interface{}{"exclamation": "!"}
 		})
 	})
+
+	// TestDirectory helper with parallel cases
+	t.Run("directory parallel", func(t *testing.T) {
+		TestPluginDir(t, "testdata/plugin-test-dir", func(tc *TestPluginCase) {
+			tc.PluginPath = path
+			tc.Global = map[string]interface{}{"exclamation": "!"}
+			tc.Parallel = true
+		})
+	})
+}
+
+func TestCaseT(t *testing.T) {
+	parent := &testingiface.RuntimeT{}
+
+	var ran []string
+	for _, name := range []string{"a", "b", "c"} {
+		ct := &caseT{T: parent, name: name}
+		ct.run(func() {
+			if name == "b" {
+				ct.Fatalf("failing %s", name)
+			}
+
+			ran = append(ran, name)
+		})
+
+		if ct.Failed() != (name == "b") {
+			t.Fatalf("bad failed state for %s: %v", name, ct.Failed())
+		}
+	}
+
+	if !reflect.DeepEqual(ran, []string{"a", "c"}) {
+		t.Fatalf("bad: %#v", ran)
+	}
+
+	if parent.Failed() {
+		t.Fatal("parent should not be failed")
+	}
+}
+
+func TestBuildPlugin_cache(t *testing.T) {
+	dir, err := filepath.Abs("testplugin")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	path, err := PluginPath(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Use a tag so that the binary is distinct from the binaries built
+	// by other tests in this run.
+	opts := &BuildOptions{
+		Tags:     []string{"sentinelcache"},
+		CacheDir: t.TempDir(),
+	}
+
+	// The first build populates the cache
+	first := pluginBinary(t, path, opts)
+	if strings.HasPrefix(first, opts.CacheDir) {
+		t.Fatalf("expected fresh build, got: %s", first)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(opts.CacheDir, "*", "plugin-test*"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("expected one cached binary, got: %#v", matches)
+	}
+
+	// Forget the binary for this run, the next build should come from
+	// the cache.
+	pluginLock.Lock()
+	delete(pluginBuilds, opts.key(path))
+	pluginLock.Unlock()
+
+	second := pluginBinary(t, path, opts)
+	if !strings.HasPrefix(second, opts.CacheDir) {
+		t.Fatalf("expected binary from cache, got: %s", second)
+	}
+}
+
+func TestBuildOptions_args(t *testing.T) {
+	opts := &BuildOptions{
+		Tags:    []string{"foo", "bar"},
+		LDFlags: "-s -w",
+		Race:    true,
+		Cover:   true,
+		Flags:   []string{"-trimpath"},
+	}
+
+	expected := []string{
+		"-tags", "foo,bar",
+		"-ldflags", "-s -w",
+		"-race",
+		"-cover",
+		"-trimpath",
+	}
+
+	if actual := opts.args(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestCollectCoverage(t *testing.T) {
+	dir, err := filepath.Abs("testplugin")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	path, err := PluginPath(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	opts := &BuildOptions{
+		Cover:     true,
+		CoverMode: "set",
+		CoverDir:  t.TempDir(),
+	}
+
+	// Run the binary outside of Sentinel. This fails the plugin handshake,
+	// but the coverage data is still written on exit.
+	runDir := t.TempDir()
+	cmd := exec.Command(pluginBinary(t, path, opts))
+	cmd.Env = append(os.Environ(), "GOCOVERDIR="+runDir)
+	cmd.Run()
+
+	if err := collectCoverage(opts, runDir); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, pattern := range []string{"covmeta.*", "covcounters.*"} {
+		matches, err := filepath.Glob(filepath.Join(opts.CoverDir, pattern))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		if len(matches) == 0 {
+			t.Fatalf("no files matching %s collected", pattern)
+		}
+	}
 }